@@ -0,0 +1,105 @@
+// Package analyzer provides the test table diagnostics of the outline parser as a go/analysis Analyzer,
+// for use with go vet -vettool, multichecker or other analysis drivers.
+package analyzer
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/config"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+const doc = `report problems in table-driven tests
+
+The tdtoutline analyzer reports duplicate, colliding, empty and badly formatted
+test case names, mixed keyed and positional test cases, duplicate test cases,
+and test tables never ranged over with t.Run or passed to a test helper. Rules
+are configured in .tdt-outline/config.json, found from the package directory and
+its parents, or in the file given with -config.`
+
+// Analyzer reports problems in the test tables of _test.go files
+var Analyzer = &analysis.Analyzer{
+	Name: "tdtoutline",
+	Doc:  doc,
+	URL:  "https://github.com/toga4/vscode-go-tdt-outline",
+	Run:  run,
+}
+
+var configPath string // -config flag
+
+func init() {
+	Analyzer.Flags.StringVar(&configPath, "config", "", "configuration file (default: "+config.Path+" in the package directory or its parents)")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	var opts *parser.Options
+	for _, file := range pass.Files {
+		tf := pass.Fset.File(file.Pos())
+		if tf == nil || !strings.HasSuffix(tf.Name(), "_test.go") {
+			continue
+		}
+
+		if opts == nil {
+			o, err := loadOptions(filepath.Dir(tf.Name()))
+			if err != nil {
+				return nil, err
+			}
+			o.TypeInfo = pass.TypesInfo
+			o.PackageDir = filepath.Dir(tf.Name())
+			opts = &o
+		}
+
+		result := parser.AnalyzeAST(pass.Fset, file, nil, *opts)
+		for _, d := range result.Diagnostics {
+			pass.Report(analysis.Diagnostic{
+				Pos:            pos(tf, d.Range.Start),
+				End:            pos(tf, d.Range.End),
+				Category:       d.Rule,
+				Message:        fmt.Sprintf("%s (%s)", d.Message, d.Rule),
+				SuggestedFixes: suggestedFixes(tf, d.Fixes),
+			})
+		}
+	}
+	return nil, nil
+}
+
+// loadOptions loads the parser options from the -config file or the configuration found from dir
+func loadOptions(dir string) (parser.Options, error) {
+	var c *config.Config
+	var err error
+	if configPath != "" {
+		c, err = config.Load(configPath)
+	} else {
+		c, err = config.LoadFor(dir)
+	}
+	if err != nil {
+		return parser.Options{}, err
+	}
+	return c.ParserOptions(), nil
+}
+
+func suggestedFixes(tf *token.File, fixes []parser.Fix) []analysis.SuggestedFix {
+	var suggested []analysis.SuggestedFix
+	for _, fix := range fixes {
+		s := analysis.SuggestedFix{Message: fix.Message}
+		for _, edit := range fix.Edits {
+			s.TextEdits = append(s.TextEdits, analysis.TextEdit{
+				Pos:     pos(tf, edit.Range.Start),
+				End:     pos(tf, edit.Range.End),
+				NewText: []byte(edit.NewText),
+			})
+		}
+		suggested = append(suggested, s)
+	}
+	return suggested
+}
+
+// pos converts a position of the parser output back to a token.Pos
+func pos(tf *token.File, l parser.Line) token.Pos {
+	return tf.Pos(l.Offset)
+}
@@ -0,0 +1,30 @@
+package a
+
+import "testing"
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input int
+	}{
+		{name: "a b", input: 1},
+		{name: "a b", input: 2},      // want `duplicate test case name "a b"; go test runs it as "a_b#01" \(duplicate-name\)`
+		{name: " padded ", input: 3}, // want `test case name " padded " has leading or trailing whitespace \(name-whitespace\)`
+		{"positional", 4},            // want `positional test case in a table of keyed test cases \(mixed-elements\)`
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.input
+		})
+	}
+}
+
+func TestUnused(t *testing.T) {
+	cases := []struct { // want `test table "cases" is never ranged over with t.Run or passed to a test helper \(unused-table\)`
+		name string
+	}{
+		{name: "never run"},
+	}
+	_ = cases
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/compare"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/git"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+// changedCase is an added or modified test case in the changed output
+type changedCase struct {
+	Name   string       `json:"name"`
+	Test   string       `json:"test"` // full go test name
+	Status string       `json:"status"`
+	Range  parser.Range `json:"range"`
+}
+
+// changedTest is a test function to run in the changed output
+type changedTest struct {
+	File     string        `json:"file"`
+	Package  string        `json:"package"`
+	Function string        `json:"function"`
+	Status   string        `json:"status"`
+	Cases    []changedCase `json:"cases,omitempty"` // empty when the whole function runs
+	Run      string        `json:"run"`
+}
+
+// changedReport is the JSON output of the changed subcommand
+type changedReport struct {
+	Packages []string      `json:"packages"`
+	Run      string        `json:"run"` // -run pattern selecting every test
+	Tests    []changedTest `json:"tests"`
+}
+
+// runChanged prints the -run patterns selecting the test functions and test cases
+// that were added or modified between a base ref and the working tree or a head ref.
+//
+// Usage: changed [-base ref] [-head ref] [-format args|json] [dir]
+func runChanged(args []string) error {
+	fs := flag.NewFlagSet("changed", flag.ContinueOnError)
+	base := fs.String("base", "HEAD", "base revision to compare against")
+	head := fs.String("head", "", "revision to compare (default: the working tree)")
+	format := fs.String("format", "args", `output format ("args" or "json")`)
+	if err := fs.Parse(args); err != nil {
+		return &usageError{err: err}
+	}
+	if *format != "args" && *format != "json" {
+		return usageErrorf("unknown format: %s", *format)
+	}
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+	topLevel, err := git.TopLevel(dir)
+	if err != nil {
+		return err
+	}
+	files, err := git.ChangedFiles(topLevel, *base, *head, "*_test.go")
+	if err != nil {
+		return err
+	}
+
+	report := changedReport{Packages: []string{}, Tests: []changedTest{}}
+	for _, file := range files {
+		tests, err := changedTests(topLevel, *base, *head, file)
+		if err != nil {
+			return err
+		}
+		if len(tests) > 0 && !slices.Contains(report.Packages, tests[0].Package) {
+			report.Packages = append(report.Packages, tests[0].Package)
+		}
+		report.Tests = append(report.Tests, tests...)
+	}
+	report.Run = combinedRunPattern(report.Tests)
+
+	if *format == "json" {
+		return json.NewEncoder(os.Stdout).Encode(report)
+	}
+
+	// One argument per line, e.g. for `xargs -d '\n' go test`
+	if len(report.Tests) == 0 {
+		return nil
+	}
+	fmt.Println("-run=" + report.Run)
+	for _, pkg := range report.Packages {
+		fmt.Println(pkg)
+	}
+	return nil
+}
+
+// changedTests compares a single changed file between the base and head revisions
+func changedTests(topLevel, base, head, file string) ([]changedTest, error) {
+	newSrc, err := readRevision(topLevel, head, file)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := loadOptions("", filepath.Join(topLevel, file))
+	if err != nil {
+		return nil, err
+	}
+	newer, err := compare.Load(file, newSrc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var older *compare.File
+	oldSrc, err := git.Show(topLevel, base, file)
+	switch {
+	case errors.Is(err, git.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		// The base version may not parse; treat the file as new in that case
+		older, _ = compare.Load(file, oldSrc, opts)
+	}
+
+	pkg, err := packagePath(filepath.Join(topLevel, filepath.Dir(file)))
+	if err != nil {
+		return nil, err
+	}
+
+	var tests []changedTest
+	for _, change := range compare.ChangedTests(older, newer) {
+		test := changedTest{
+			File:     file,
+			Package:  pkg,
+			Function: change.Function,
+			Status:   string(change.Status),
+			Run:      parser.RunPattern(change.Function),
+		}
+		if len(change.Cases) > 0 {
+			names := make([]string, len(change.Cases))
+			for i, c := range change.Cases {
+				names[i] = c.TestName
+				test.Cases = append(test.Cases, changedCase{
+					Name:   c.Symbol.Name,
+					Test:   c.TestName,
+					Status: string(c.Status),
+					Range:  c.Symbol.Range,
+				})
+			}
+			test.Run = parser.RunPattern(names...)
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// readRevision reads file from the working tree if rev is empty, otherwise from rev
+func readRevision(topLevel, rev, file string) ([]byte, error) {
+	if rev == "" {
+		return os.ReadFile(filepath.Join(topLevel, file))
+	}
+	return git.Show(topLevel, rev, file)
+}
+
+// packagePath returns the go test package argument for dir relative to the working directory
+func packagePath(dir string) (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(wd, dir)
+	if err != nil {
+		return "", err
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return rel, nil
+	}
+	return "./" + rel, nil
+}
+
+// combinedRunPattern returns a single -run pattern selecting all tests.
+// Whole test functions cannot be combined with case patterns without filtering out
+// their other cases, so the pattern falls back to whole functions in that case.
+func combinedRunPattern(tests []changedTest) string {
+	wholeFunction := slices.ContainsFunc(tests, func(t changedTest) bool {
+		return len(t.Cases) == 0
+	})
+
+	var names []string
+	for _, t := range tests {
+		if wholeFunction || len(t.Cases) == 0 {
+			names = append(names, t.Function)
+			continue
+		}
+		for _, c := range t.Cases {
+			names = append(names, c.Test)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return parser.RunPattern(names...)
+}
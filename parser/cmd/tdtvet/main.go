@@ -0,0 +1,14 @@
+// Command tdtvet reports problems in table-driven tests.
+//
+// It runs on its own (tdtvet ./...) or as a vet tool (go vet -vettool=$(which tdtvet) ./...).
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/compare"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/git"
+)
+
+// runDiff compares the test outline of two versions of a test file, given either as
+// two paths or as a git revision and a path (compared against the working tree).
+//
+// Usage: diff [-format markdown|json] <old_file> <new_file> | <rev> <file>
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	format := fs.String("format", "markdown", `output format ("markdown" or "json")`)
+	if err := fs.Parse(args); err != nil {
+		return &usageError{err: err}
+	}
+	if fs.NArg() != 2 {
+		return usageErrorf("usage: diff [-format markdown|json] <old_file> <new_file> | <rev> <file>")
+	}
+	if *format != "markdown" && *format != "json" {
+		return usageErrorf("unknown format: %s", *format)
+	}
+
+	oldSrc, newPath, err := readOldVersion(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	newSrc, err := os.ReadFile(newPath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	opts, err := loadOptions("", newPath)
+	if err != nil {
+		return err
+	}
+	var older *compare.File
+	if oldSrc != nil {
+		older, err = compare.Load(fs.Arg(0), oldSrc, opts)
+		if err != nil {
+			return err
+		}
+	}
+	newer, err := compare.Load(newPath, newSrc, opts)
+	if err != nil {
+		return err
+	}
+
+	diffs := compare.Diff(older, newer)
+	if *format == "json" {
+		if diffs == nil {
+			diffs = []compare.FunctionDiff{}
+		}
+		return json.NewEncoder(os.Stdout).Encode(diffs)
+	}
+	writeMarkdownDiff(os.Stdout, diffs)
+	return nil
+}
+
+// readOldVersion reads the old version of the file from the first argument, which is
+// either a path or a git revision. It returns nil content if the file does not exist in the revision.
+func readOldVersion(oldArg, newPath string) ([]byte, string, error) {
+	if _, err := os.Stat(oldArg); err == nil {
+		src, err := os.ReadFile(oldArg)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read file: %w", err)
+		}
+		return src, newPath, nil
+	}
+
+	topLevel, err := git.TopLevel(filepath.Dir(newPath))
+	if err != nil {
+		return nil, "", err
+	}
+	abs, err := filepath.Abs(newPath)
+	if err != nil {
+		return nil, "", err
+	}
+	rel, err := filepath.Rel(topLevel, abs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	src, err := git.Show(topLevel, oldArg, filepath.ToSlash(rel))
+	if errors.Is(err, git.ErrNotExist) {
+		return nil, newPath, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return src, newPath, nil
+}
+
+// writeMarkdownDiff writes the diff as a Markdown list suitable for PR descriptions
+func writeMarkdownDiff(w io.Writer, diffs []compare.FunctionDiff) {
+	if len(diffs) == 0 {
+		_, _ = fmt.Fprintln(w, "No test case changes.")
+		return
+	}
+
+	for i, fn := range diffs {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		heading := "#### " + code(fn.Function)
+		if fn.Status != compare.StatusModified {
+			heading += " (" + string(fn.Status) + ")"
+		}
+		_, _ = fmt.Fprintln(w, heading)
+
+		for _, c := range fn.Cases {
+			var line string
+			switch c.Status {
+			case compare.StatusRenamed:
+				line = fmt.Sprintf("- Renamed %s → %s", code(c.OldName), code(c.Name))
+			default:
+				line = fmt.Sprintf("- %s %s", capitalize(string(c.Status)), code(c.Name))
+			}
+			if len(c.Fields) > 0 {
+				var changes []string
+				for _, f := range c.Fields {
+					changes = append(changes, fieldChange(f))
+				}
+				line += ": " + strings.Join(changes, ", ")
+			}
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+}
+
+func fieldChange(f compare.FieldChange) string {
+	name := f.Name
+	if name == "" {
+		name = "value"
+	}
+	switch {
+	case f.Old == "":
+		return fmt.Sprintf("%s added (%s)", name, code(f.New))
+	case f.New == "":
+		return fmt.Sprintf("%s removed (%s)", name, code(f.Old))
+	default:
+		return fmt.Sprintf("%s %s → %s", name, code(f.Old), code(f.New))
+	}
+}
+
+// code formats s as a Markdown code span
+func code(s string) string {
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/outline"
+)
+
+// Exit codes
+const (
+	exitInternal = 1 // unexpected failure, including failing go and git commands
+	exitUsage    = 2 // invalid arguments, flags or configuration
+	exitIO       = 3 // a file could not be read or written
+	exitSyntax   = 4 // the file has syntax errors (-strict)
+)
+
+// Error kinds of the JSON error output
+const (
+	kindInternal = "internal"
+	kindUsage    = "usage"
+	kindIO       = "io"
+	kindSyntax   = "syntax"
+)
+
+// usageError is an error in the command line arguments or the configuration
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// usageErrorf returns a usageError with a formatted message
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// cliError is the JSON error output written with -error-format json
+type cliError struct {
+	Kind     string            `json:"kind"`
+	Message  string            `json:"message"`
+	File     string            `json:"file,omitempty"`
+	Position *outline.Position `json:"position,omitempty"` // position of the first syntax error
+}
+
+// classify returns the JSON error and the exit code for err
+func classify(err error) (cliError, int) {
+	e := cliError{Kind: kindInternal, Message: err.Error()}
+	var usageErr *usageError
+	var parseErr *outline.ParseError
+	var pathErr *fs.PathError
+	switch {
+	case errors.As(err, &usageErr):
+		e.Kind = kindUsage
+		return e, exitUsage
+	case errors.As(err, &parseErr):
+		pos := parseErr.Pos()
+		e.Kind, e.File, e.Position = kindSyntax, parseErr.Filename, &pos
+		return e, exitSyntax
+	case errors.As(err, &pathErr):
+		e.Kind, e.File = kindIO, pathErr.Path
+		return e, exitIO
+	}
+	return e, exitInternal
+}
+
+// fail reports err as text on stderr or, with jsonErrors, as a JSON object on stdout, and exits
+func fail(jsonErrors bool, context string, err error) {
+	e, code := classify(err)
+	if jsonErrors {
+		if err := json.NewEncoder(os.Stdout).Encode(struct {
+			Error cliError `json:"error"`
+		}{e}); err != nil {
+			log.Printf("Failed to encode error: %v", err)
+		}
+	} else {
+		log.Printf("%s: %v", context, err)
+	}
+	os.Exit(code)
+}
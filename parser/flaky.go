@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/gotest"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/history"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+// flakyCase is the flakiness report of a single test case
+type flakyCase struct {
+	Test            string       `json:"test"` // full go test name
+	Name            string       `json:"name"`
+	File            string       `json:"file"`
+	Range           parser.Range `json:"range"`
+	Runs            int          `json:"runs"`
+	Failures        int          `json:"failures"`
+	Skips           int          `json:"skips"`
+	FailureRate     float64      `json:"failureRate"`
+	MeanElapsed     float64      `json:"meanElapsed"` // seconds
+	StdDevElapsed   float64      `json:"stdDevElapsed"`
+	VarianceElapsed float64      `json:"varianceElapsed"`
+	MinElapsed      float64      `json:"minElapsed"`
+	MaxElapsed      float64      `json:"maxElapsed"`
+}
+
+// runFlaky runs the selected test cases of a file repeatedly and reports
+// the failure rate and timing variance of each case.
+//
+// Usage: flaky [-count N] [-race] [-shuffle on|off|N] [-history=false] <file_path> [test[/case]...]
+func runFlaky(args []string) error {
+	fs := flag.NewFlagSet("flaky", flag.ContinueOnError)
+	count := fs.Int("count", 10, "number of times to run each test case")
+	race := fs.Bool("race", false, "enable the race detector")
+	shuffle := fs.String("shuffle", "", `randomize the execution order ("on", "off" or a seed)`)
+	recordHistory := fs.Bool("history", true, "append elapsed times to the module's "+history.Path)
+	if err := fs.Parse(args); err != nil {
+		return &usageError{err: err}
+	}
+	if fs.NArg() < 1 {
+		return usageErrorf("usage: flaky [-count N] [-race] [-shuffle on|off|N] [-history=false] <file_path> [test[/case]...]")
+	}
+	if *count < 1 {
+		return usageErrorf("count must be positive: %d", *count)
+	}
+
+	filePath := fs.Arg(0)
+	opts, err := loadOptions("", filePath)
+	if err != nil {
+		return err
+	}
+	result, err := parser.AnalyzeFile(filePath, opts)
+	if err != nil {
+		return err
+	}
+
+	cases := selectTestCases(parser.TestCases(result.Symbols), fs.Args()[1:])
+	if len(cases) == 0 {
+		return usageErrorf("no test cases selected in %s", filePath)
+	}
+
+	names := make([]string, len(cases))
+	for i, c := range cases {
+		names[i] = c.TestName
+	}
+	events, err := gotest.Run(context.Background(), gotest.Options{
+		Dir:     filepath.Dir(filePath),
+		Run:     parser.RunPattern(names...),
+		Count:   *count,
+		Race:    *race,
+		Shuffle: *shuffle,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *recordHistory {
+		if err := appendHistory(filePath, cases, events); err != nil {
+			return err
+		}
+	}
+
+	summaries := gotest.Summarize(events)
+	report := make([]flakyCase, 0, len(cases))
+	for _, c := range cases {
+		s, ok := summaries[c.TestName]
+		if !ok {
+			s = &gotest.Summary{Test: c.TestName}
+		}
+		report = append(report, flakyCase{
+			Test:            c.TestName,
+			Name:            c.Symbol.Name,
+			File:            filePath,
+			Range:           c.Symbol.Range,
+			Runs:            s.Runs,
+			Failures:        s.Failures,
+			Skips:           s.Skips,
+			FailureRate:     s.FailureRate(),
+			MeanElapsed:     s.Mean(),
+			StdDevElapsed:   s.StdDev(),
+			VarianceElapsed: s.Variance(),
+			MinElapsed:      s.Min(),
+			MaxElapsed:      s.Max(),
+		})
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(report)
+}
+
+// selectTestCases filters test cases by "TestXxx" or "TestXxx/case name" selectors.
+// All test cases are selected when no selector is given.
+func selectTestCases(cases []parser.TestCase, selectors []string) []parser.TestCase {
+	if len(selectors) == 0 {
+		return cases
+	}
+	var selected []parser.TestCase
+	for _, c := range cases {
+		if slices.Contains(selectors, c.Function) || slices.Contains(selectors, c.Function+"/"+c.Symbol.Name) {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+// appendHistory records the elapsed time of every completed run of the selected test cases
+// in the history file of the module containing filePath.
+func appendHistory(filePath string, cases []parser.TestCase, events []gotest.Event) error {
+	root, err := gotest.FindModuleRoot(filepath.Dir(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to locate module for history: %w", err)
+	}
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]string{}
+	for _, c := range cases {
+		names[c.TestName] = c.Symbol.Name
+	}
+
+	var records []history.Record
+	for _, ev := range events {
+		name, ok := names[ev.Test]
+		if !ok || (ev.Action != "pass" && ev.Action != "fail") {
+			continue
+		}
+		records = append(records, history.Record{
+			Time:    ev.Time,
+			File:    filepath.ToSlash(rel),
+			Test:    ev.Test,
+			Name:    name,
+			Action:  ev.Action,
+			Elapsed: ev.Elapsed,
+		})
+	}
+	return history.Append(root, records)
+}
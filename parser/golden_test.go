@@ -53,7 +53,7 @@ func TestGoldenFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 	binaryPath := filepath.Join(tmpDir, "parser")
 
-	cmd := exec.Command("go", "build", "-o", binaryPath, "./main.go")
+	cmd := exec.Command("go", "build", "-o", binaryPath, ".")
 	if output, err := cmd.CombinedOutput(); err != nil {
 		t.Fatalf("Failed to build parser: %v\nOutput: %s", err, output)
 	}
@@ -65,6 +65,9 @@ func TestGoldenFiles(t *testing.T) {
 		t.Fatalf("Failed to read testdata directory: %v", err)
 	}
 	for _, file := range files {
+		if file.IsDir() {
+			continue // fixtures that need a configuration, covered by the parser tests
+		}
 		inputFile := filepath.Join("internal/parser/testdata", file.Name())
 		goldenFile := filepath.Join(goldenDir, file.Name()+".json")
 
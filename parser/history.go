@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/gotest"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/history"
+)
+
+// historyReport is the output of the history subcommand
+type historyReport struct {
+	Slowest     []history.Trend      `json:"slowest"`
+	Regressions []history.Regression `json:"regressions"`
+	Trends      []history.Trend      `json:"trends,omitempty"`
+}
+
+// runHistory reports the slowest test cases, regressed test cases and per-case trends
+// recorded in the history file of the module containing the given directory.
+//
+// Usage: history [-top N] [-window N] [-factor F] [-min-delta S] [-trends] [dir]
+func runHistory(args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	top := fs.Int("top", 10, "number of slowest test cases to report")
+	window := fs.Int("window", 10, "number of previous runs the latest run is compared against")
+	factor := fs.Float64("factor", 1.5, "latest/median ratio above which a test case is regressed")
+	minDelta := fs.Float64("min-delta", 0.05, "minimum slowdown in seconds to report a regression")
+	trends := fs.Bool("trends", false, "include the trend data of every test case")
+	if err := fs.Parse(args); err != nil {
+		return &usageError{err: err}
+	}
+	if *top < 1 {
+		return usageErrorf("top must be positive: %d", *top)
+	}
+	if *window < 1 {
+		return usageErrorf("window must be positive: %d", *window)
+	}
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+	root, err := gotest.FindModuleRoot(dir)
+	if err != nil {
+		return err
+	}
+
+	records, err := history.Load(root)
+	if err != nil {
+		return err
+	}
+
+	all := history.Trends(records)
+	report := historyReport{
+		Slowest:     history.Slowest(all, *top),
+		Regressions: history.Regressions(all, *window, *factor, *minDelta),
+	}
+	if report.Slowest == nil {
+		report.Slowest = []history.Trend{}
+	}
+	if report.Regressions == nil {
+		report.Regressions = []history.Regression{}
+	}
+	if *trends {
+		report.Trends = all
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(report)
+}
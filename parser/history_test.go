@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestRunHistoryRejectsNonPositiveFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "negative top", args: []string{"-top", "-1", "."}},
+		{name: "zero top", args: []string{"-top", "0", "."}},
+		{name: "negative window", args: []string{"-window", "-1", "."}},
+		{name: "zero window", args: []string{"-window", "0", "."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := runHistory(tt.args)
+			if err == nil {
+				t.Fatal("runHistory() error = nil, want usage error")
+			}
+			if _, code := classify(err); code != exitUsage {
+				t.Errorf("exit code = %d, want %d (error: %v)", code, exitUsage, err)
+			}
+		})
+	}
+}
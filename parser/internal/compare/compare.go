@@ -0,0 +1,195 @@
+// Package compare compares the test outlines of two versions of a Go test file.
+package compare
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	goparser "go/parser"
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+// File is a parsed version of a test file
+type File struct {
+	Src     []byte
+	Symbols []parser.Symbol
+
+	funcs []testFunc // every test and benchmark function, including those without test cases
+}
+
+// testFunc is a test or benchmark function declared in a file
+type testFunc struct {
+	name       string
+	start, end int // byte offsets of the declaration
+}
+
+// Load parses a version of a test file with the given parser options.
+// Grouping options are ignored, as test cases are compared one by one.
+func Load(filename string, src []byte, opts parser.Options) (*File, error) {
+	opts.GroupDelimiter, opts.GroupFields, opts.GroupTables = "", nil, false
+	result, err := parser.Analyze(filename, bytes.NewReader(src), opts)
+	if err != nil {
+		return nil, err
+	}
+	funcs, err := testFuncs(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Src: src, Symbols: result.Symbols, funcs: funcs}, nil
+}
+
+// testFuncs returns the test and benchmark functions declared in src, as far as it parses
+func testFuncs(filename string, src []byte) ([]testFunc, error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, filename, src, goparser.AllErrors|goparser.SkipObjectResolution)
+	var syntaxErrors scanner.ErrorList
+	if err != nil && (!errors.As(err, &syntaxErrors) || file == nil) {
+		return nil, err
+	}
+
+	var funcs []testFunc
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv != nil || funcDecl.Body == nil {
+			continue
+		}
+		name := funcDecl.Name.Name
+		if !strings.HasPrefix(name, "Test") && !strings.HasPrefix(name, "Benchmark") {
+			continue
+		}
+		funcs = append(funcs, testFunc{
+			name:  name,
+			start: fset.Position(funcDecl.Pos()).Offset,
+			end:   fset.Position(funcDecl.End()).Offset,
+		})
+	}
+	return funcs, nil
+}
+
+// Status describes how a test function or test case changed
+type Status string
+
+const (
+	StatusAdded    Status = "added"
+	StatusModified Status = "modified"
+)
+
+// Change is a test function whose tests need to run again
+type Change struct {
+	Function string
+	Status   Status
+	// Cases lists the added or modified test cases when only they changed.
+	// It is empty when the whole test function needs to run.
+	Cases []CaseChange
+}
+
+// CaseChange is an added or modified test case
+type CaseChange struct {
+	parser.TestCase
+	Status Status
+}
+
+// ChangedTests returns the test functions and test cases of newer that were added or
+// modified relative to older. older may be nil when the file is new.
+//
+// A test function is selected as a whole when it is new, when it has no test cases, or
+// when anything other than its test cases changed; otherwise only its added and modified
+// test cases are selected.
+func ChangedTests(older, newer *File) []Change {
+	var changes []Change
+	for _, decl := range newer.funcs {
+		if strings.HasPrefix(decl.name, "Benchmark") {
+			continue // not selected by -run
+		}
+		oldDecl, ok := findFunc(older, decl.name)
+		if !ok {
+			changes = append(changes, Change{Function: decl.name, Status: StatusAdded})
+			continue
+		}
+		if parser.Normalize(string(older.Src[oldDecl.start:oldDecl.end])) == parser.Normalize(string(newer.Src[decl.start:decl.end])) {
+			continue
+		}
+
+		oldFn, oldOK := findFunction(older, decl.name)
+		fn, ok := findFunction(newer, decl.name)
+		if !ok || !oldOK || skeleton(older.Src, oldFn) != skeleton(newer.Src, fn) {
+			changes = append(changes, Change{Function: decl.name, Status: StatusModified})
+			continue
+		}
+
+		oldCases := map[string]string{}
+		for _, c := range parser.TestCases([]parser.Symbol{oldFn}) {
+			oldCases[c.TestName] = parser.Normalize(text(older.Src, c.Symbol.Range))
+		}
+
+		var cases []CaseChange
+		for _, c := range parser.TestCases([]parser.Symbol{fn}) {
+			oldText, ok := oldCases[c.TestName]
+			switch {
+			case !ok:
+				cases = append(cases, CaseChange{TestCase: c, Status: StatusAdded})
+			case oldText != parser.Normalize(text(newer.Src, c.Symbol.Range)):
+				cases = append(cases, CaseChange{TestCase: c, Status: StatusModified})
+			}
+		}
+		if len(cases) > 0 {
+			changes = append(changes, Change{Function: fn.Name, Status: StatusModified, Cases: cases})
+		}
+	}
+	return changes
+}
+
+func findFunc(f *File, name string) (testFunc, bool) {
+	if f == nil {
+		return testFunc{}, false
+	}
+	for _, fn := range f.funcs {
+		if fn.name == name {
+			return fn, true
+		}
+	}
+	return testFunc{}, false
+}
+
+func findFunction(f *File, name string) (parser.Symbol, bool) {
+	if f == nil {
+		return parser.Symbol{}, false
+	}
+	for _, fn := range f.Symbols {
+		if fn.Name == name {
+			return fn, true
+		}
+	}
+	return parser.Symbol{}, false
+}
+
+// skeleton returns the normalized text of a test function with its test cases removed
+func skeleton(src []byte, fn parser.Symbol) string {
+	start, end := fn.Range.Start.Offset, fn.Range.End.Offset
+	var b strings.Builder
+	pos := start
+	for _, c := range fn.Children {
+		cStart, cEnd := c.Range.Start.Offset, c.Range.End.Offset
+		if cStart < pos || c.Placeholder {
+			continue // placeholder cases cannot be selected on their own
+		}
+		b.Write(src[pos:cStart])
+		// Drop the separator of the removed case so that adding or removing cases
+		// does not change the skeleton
+		pos = cEnd
+		if rest := bytes.TrimLeft(src[pos:end], " \t\r\n"); len(rest) > 0 && rest[0] == ',' {
+			pos = end - len(rest) + 1
+		}
+	}
+	b.Write(src[pos:end])
+	return parser.Normalize(b.String())
+}
+
+// text returns the source text covered by r
+func text(src []byte, r parser.Range) string {
+	return string(src[r.Start.Offset:r.End.Offset])
+}
@@ -0,0 +1,160 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+const baseSrc = `package example
+
+import "testing"
+
+func TestExample(t *testing.T) {
+	tests := []struct {
+		name  string
+		input int
+	}{
+		{name: "one", input: 1},
+		{name: "two", input: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {})
+	}
+}
+`
+
+const plainSrc = `
+func TestPlain(t *testing.T) {
+	want := 1
+	if got := 1; got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+`
+
+type changeSummary struct {
+	Function string
+	Status   Status
+	Cases    map[string]Status
+}
+
+func TestChangedTests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		older string
+		newer string
+		want  []changeSummary
+	}{
+		{
+			name:  "unchanged",
+			older: baseSrc,
+			newer: baseSrc,
+			want:  nil,
+		},
+		{
+			name:  "new file",
+			older: "",
+			newer: baseSrc,
+			want:  []changeSummary{{Function: "TestExample", Status: StatusAdded}},
+		},
+		{
+			name:  "reformatted case",
+			older: baseSrc,
+			newer: replace(baseSrc, `{name: "one", input: 1},`, "{\n\t\t\tname: \"one\",\n\t\t\tinput: 1,\n\t\t},"),
+			want:  nil,
+		},
+		{
+			name:  "modified case",
+			older: baseSrc,
+			newer: replace(baseSrc, `{name: "two", input: 2}`, `{name: "two", input: 22}`),
+			want: []changeSummary{
+				{Function: "TestExample", Status: StatusModified, Cases: map[string]Status{"TestExample/two": StatusModified}},
+			},
+		},
+		{
+			name:  "added case",
+			older: baseSrc,
+			newer: replace(baseSrc, `{name: "two", input: 2},`, "{name: \"two\", input: 2},\n\t\t{name: \"three\", input: 3},"),
+			want: []changeSummary{
+				{Function: "TestExample", Status: StatusModified, Cases: map[string]Status{"TestExample/three": StatusAdded}},
+			},
+		},
+		{
+			name:  "removed case",
+			older: baseSrc,
+			newer: replace(baseSrc, "\t\t{name: \"two\", input: 2},\n", ""),
+			want:  nil,
+		},
+		{
+			name:  "modified unnamed case",
+			older: replace(baseSrc, `{name: "two", input: 2},`, `{input: 2},`),
+			newer: replace(baseSrc, `{name: "two", input: 2},`, `{input: 22},`),
+			want:  []changeSummary{{Function: "TestExample", Status: StatusModified}},
+		},
+		{
+			name:  "modified plain test function",
+			older: baseSrc + plainSrc,
+			newer: baseSrc + replace(plainSrc, "want := 1", "want := 2"),
+			want:  []changeSummary{{Function: "TestPlain", Status: StatusModified}},
+		},
+		{
+			name:  "added plain test function",
+			older: baseSrc,
+			newer: baseSrc + plainSrc,
+			want:  []changeSummary{{Function: "TestPlain", Status: StatusAdded}},
+		},
+		{
+			name:  "modified loop body",
+			older: baseSrc,
+			newer: replace(baseSrc, `t.Run(tt.name, func(t *testing.T) {})`, `t.Run(tt.name, func(t *testing.T) { t.Parallel() })`),
+			want:  []changeSummary{{Function: "TestExample", Status: StatusModified}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var older *File
+			if tt.older != "" {
+				var err error
+				older, err = Load("example_test.go", []byte(tt.older), parser.Options{})
+				if err != nil {
+					t.Fatalf("Load() error = %v", err)
+				}
+			}
+			newer, err := Load("example_test.go", []byte(tt.newer), parser.Options{})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			var got []changeSummary
+			for _, c := range ChangedTests(older, newer) {
+				s := changeSummary{Function: c.Function, Status: c.Status}
+				for _, cc := range c.Cases {
+					if s.Cases == nil {
+						s.Cases = map[string]Status{}
+					}
+					s.Cases[cc.TestName] = cc.Status
+				}
+				got = append(got, s)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ChangedTests() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func replace(s, old, new string) string {
+	if !strings.Contains(s, old) {
+		panic("replace: " + old + " not found")
+	}
+	return strings.Replace(s, old, new, 1)
+}
@@ -0,0 +1,197 @@
+package compare
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+const (
+	StatusRemoved Status = "removed"
+	StatusRenamed Status = "renamed"
+)
+
+// RenameThreshold is the minimum field-value similarity for a removed and an added
+// test case to be reported as a rename
+const RenameThreshold = 0.5
+
+// FunctionDiff describes how the test cases of a test function changed
+type FunctionDiff struct {
+	Function string     `json:"function"`
+	Status   Status     `json:"status"`
+	Cases    []CaseDiff `json:"cases"`
+}
+
+// CaseDiff describes how a test case changed
+type CaseDiff struct {
+	Status     Status        `json:"status"`
+	Name       string        `json:"name"`              // name in the newer version, or in the older version if removed
+	OldName    string        `json:"oldName,omitempty"` // name in the older version if renamed
+	Similarity float64       `json:"similarity,omitempty"`
+	Fields     []FieldChange `json:"fields,omitempty"`
+	Range      parser.Range  `json:"range"` // range in the newer version, or in the older version if removed
+}
+
+// FieldChange describes a changed field value of a test case.
+// Old is empty for added fields and New is empty for removed fields.
+type FieldChange struct {
+	Name string `json:"name"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// Diff compares the test outlines of two versions of a test file.
+// Removed and added test cases whose field values are similar enough are reported as renamed.
+func Diff(older, newer *File) []FunctionDiff {
+	var diffs []FunctionDiff
+	for _, fn := range newer.Symbols {
+		oldFn, ok := findFunction(older, fn.Name)
+		if !ok {
+			diffs = append(diffs, FunctionDiff{Function: fn.Name, Status: StatusAdded, Cases: allCases(StatusAdded, fn)})
+			continue
+		}
+		if cases := diffCases(oldFn, fn); len(cases) > 0 {
+			diffs = append(diffs, FunctionDiff{Function: fn.Name, Status: StatusModified, Cases: cases})
+		}
+	}
+	if older != nil {
+		for _, fn := range older.Symbols {
+			if _, ok := findFunction(newer, fn.Name); !ok {
+				diffs = append(diffs, FunctionDiff{Function: fn.Name, Status: StatusRemoved, Cases: allCases(StatusRemoved, fn)})
+			}
+		}
+	}
+	return diffs
+}
+
+func allCases(status Status, fn parser.Symbol) []CaseDiff {
+	cases := make([]CaseDiff, len(fn.Children))
+	for i, c := range fn.Children {
+		cases[i] = CaseDiff{Status: status, Name: c.Name, Range: c.Range}
+	}
+	return cases
+}
+
+// diffCases compares the test cases of two versions of a test function
+func diffCases(oldFn, newFn parser.Symbol) []CaseDiff {
+	oldCases := map[string]parser.Symbol{}
+	for _, c := range oldFn.Children {
+		if _, ok := oldCases[c.Name]; !ok {
+			oldCases[c.Name] = c
+		}
+	}
+	newNames := map[string]bool{}
+	for _, c := range newFn.Children {
+		newNames[c.Name] = true
+	}
+
+	var added []parser.Symbol
+	var diffs []CaseDiff
+	for _, c := range newFn.Children {
+		old, ok := oldCases[c.Name]
+		if !ok {
+			added = append(added, c)
+			continue
+		}
+		if changes := diffFields(old.Fields, c.Fields); len(changes) > 0 {
+			diffs = append(diffs, CaseDiff{Status: StatusModified, Name: c.Name, Fields: changes, Range: c.Range})
+		}
+	}
+	var removed []parser.Symbol
+	for _, c := range oldFn.Children {
+		if !newNames[c.Name] {
+			removed = append(removed, c)
+		}
+	}
+
+	// Match renamed cases greedily, most similar pairs first
+	type pair struct {
+		old, new   int
+		similarity float64
+	}
+	var pairs []pair
+	for i, o := range removed {
+		for j, n := range added {
+			if s := similarity(o.Fields, n.Fields); s >= RenameThreshold {
+				pairs = append(pairs, pair{old: i, new: j, similarity: s})
+			}
+		}
+	}
+	slices.SortStableFunc(pairs, func(a, b pair) int {
+		return cmp.Compare(b.similarity, a.similarity)
+	})
+	renamedFrom := map[int]pair{}
+	oldMatched := map[int]bool{}
+	for _, p := range pairs {
+		if _, ok := renamedFrom[p.new]; ok || oldMatched[p.old] {
+			continue
+		}
+		renamedFrom[p.new] = p
+		oldMatched[p.old] = true
+	}
+
+	for j, n := range added {
+		p, ok := renamedFrom[j]
+		if !ok {
+			diffs = append(diffs, CaseDiff{Status: StatusAdded, Name: n.Name, Range: n.Range})
+			continue
+		}
+		o := removed[p.old]
+		diffs = append(diffs, CaseDiff{
+			Status:     StatusRenamed,
+			Name:       n.Name,
+			OldName:    o.Name,
+			Similarity: p.similarity,
+			Fields:     diffFields(o.Fields, n.Fields),
+			Range:      n.Range,
+		})
+	}
+	for i, o := range removed {
+		if !oldMatched[i] {
+			diffs = append(diffs, CaseDiff{Status: StatusRemoved, Name: o.Name, Range: o.Range})
+		}
+	}
+	return diffs
+}
+
+// diffFields lists the fields whose values differ between two versions of a test case
+func diffFields(older, newer []parser.Field) []FieldChange {
+	var changes []FieldChange
+	oldValues := map[string]string{}
+	for _, f := range older {
+		oldValues[f.Name] = f.Value
+	}
+	newValues := map[string]string{}
+	for _, f := range newer {
+		newValues[f.Name] = f.Value
+		if old, ok := oldValues[f.Name]; !ok || old != f.Value {
+			changes = append(changes, FieldChange{Name: f.Name, Old: old, New: f.Value})
+		}
+	}
+	for _, f := range older {
+		if _, ok := newValues[f.Name]; !ok {
+			changes = append(changes, FieldChange{Name: f.Name, Old: f.Value})
+		}
+	}
+	return changes
+}
+
+// similarity returns the Jaccard similarity of the field values of two test cases
+func similarity(a, b []parser.Field) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	counts := map[parser.Field]int{}
+	for _, f := range a {
+		counts[f]++
+	}
+	common := 0
+	for _, f := range b {
+		if counts[f] > 0 {
+			counts[f]--
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
@@ -0,0 +1,135 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+const tableSrc = `package example
+
+import "testing"
+
+func TestExample(t *testing.T) {
+	tests := []struct {
+		name  string
+		input int
+		want  string
+		err   bool
+	}{
+		{name: "one", input: 1, want: "1", err: false},
+		{name: "two", input: 2, want: "2", err: false},
+		{name: "three", input: 3, want: "3", err: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {})
+	}
+}
+`
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		older string
+		newer string
+		want  []FunctionDiff
+	}{
+		{
+			name:  "unchanged",
+			older: tableSrc,
+			newer: tableSrc,
+			want:  nil,
+		},
+		{
+			name:  "added and removed cases",
+			older: tableSrc,
+			newer: replace(tableSrc, `{name: "two", input: 2, want: "2", err: false}`, `{name: "four", input: 4, want: "4", err: false}`),
+			want: []FunctionDiff{
+				{Function: "TestExample", Status: StatusModified, Cases: []CaseDiff{
+					{Status: StatusAdded, Name: "four"},
+					{Status: StatusRemoved, Name: "two"},
+				}},
+			},
+		},
+		{
+			name:  "renamed case",
+			older: tableSrc,
+			newer: replace(tableSrc, `{name: "two", input: 2, want: "2", err: false}`, `{name: "deux", input: 2, want: "2", err: false}`),
+			want: []FunctionDiff{
+				{Function: "TestExample", Status: StatusModified, Cases: []CaseDiff{
+					{Status: StatusRenamed, Name: "deux", OldName: "two", Similarity: 1},
+				}},
+			},
+		},
+		{
+			name:  "renamed and modified case",
+			older: tableSrc,
+			newer: replace(tableSrc, `{name: "two", input: 2, want: "2", err: false}`, `{name: "deux", input: 2, want: "two", err: false}`),
+			want: []FunctionDiff{
+				{Function: "TestExample", Status: StatusModified, Cases: []CaseDiff{
+					{Status: StatusRenamed, Name: "deux", OldName: "two", Similarity: 0.5, Fields: []FieldChange{
+						{Name: "want", Old: `"2"`, New: `"two"`},
+					}},
+				}},
+			},
+		},
+		{
+			name:  "dissimilar cases are not renamed",
+			older: tableSrc,
+			newer: replace(tableSrc, `{name: "two", input: 2, want: "2", err: false}`, `{name: "deux", input: 22, want: "two", err: false}`),
+			want: []FunctionDiff{
+				{Function: "TestExample", Status: StatusModified, Cases: []CaseDiff{
+					{Status: StatusAdded, Name: "deux"},
+					{Status: StatusRemoved, Name: "two"},
+				}},
+			},
+		},
+		{
+			name:  "modified case",
+			older: tableSrc,
+			newer: replace(tableSrc, `{name: "three", input: 3, want: "3", err: false}`, "{\n\t\t\tname:  \"three\",\n\t\t\tinput: 33,\n\t\t\twant:  \"3\",\n\t\t\terr:   false,\n\t\t}"),
+			want: []FunctionDiff{
+				{Function: "TestExample", Status: StatusModified, Cases: []CaseDiff{
+					{Status: StatusModified, Name: "three", Fields: []FieldChange{{Name: "input", Old: "3", New: "33"}}},
+				}},
+			},
+		},
+		{
+			name:  "removed test function",
+			older: tableSrc,
+			newer: "package example\n",
+			want: []FunctionDiff{
+				{Function: "TestExample", Status: StatusRemoved, Cases: []CaseDiff{
+					{Status: StatusRemoved, Name: "one"},
+					{Status: StatusRemoved, Name: "two"},
+					{Status: StatusRemoved, Name: "three"},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			older, err := Load("example_test.go", []byte(tt.older), parser.Options{})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			newer, err := Load("example_test.go", []byte(tt.newer), parser.Options{})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			got := Diff(older, newer)
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(CaseDiff{}, "Range")); diff != "" {
+				t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
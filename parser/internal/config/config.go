@@ -0,0 +1,125 @@
+// Package config loads the project configuration file.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+// Path is the location of the configuration file relative to the directory it applies to
+const Path = ".tdt-outline/config.json"
+
+// Config is the content of the configuration file
+type Config struct {
+	// NameFields are the struct field names holding the test case name, in order of preference
+	NameFields []string `json:"nameFields"`
+
+	// Recognizers are the enabled test table recognizers ("slice", "map", "range-inline", "var-decl",
+	// "runner", "loop") and patterns (by name, or "<kind>:<func>" for unnamed patterns)
+	Recognizers []string `json:"recognizers"`
+
+	// MaxExpansions caps the test cases generated from a subtest name computed over literal ranges
+	MaxExpansions int `json:"maxExpansions"`
+
+	// Patterns are user-defined test case patterns, such as tables passed to helper functions
+	Patterns []parser.Pattern `json:"patterns"`
+
+	// GroupDelimiter splits test case names into groups, such as ":" or " / "
+	GroupDelimiter string `json:"groupDelimiter"`
+
+	// GroupFields are the struct field names whose values group test cases, such as "group" or "category"
+	GroupFields []string `json:"groupFields"`
+
+	// GroupTables places the test cases of each table under a symbol of the table in functions with several tables
+	GroupTables bool `json:"groupTables"`
+
+	// AllTables outlines every table literal of test functions, including those never run as subtests
+	AllTables bool `json:"allTables"`
+
+	Lint Lint `json:"lint"`
+}
+
+// Lint configures the diagnostics reported on test tables
+type Lint struct {
+	// Rules overrides the severity of lint rules by rule ID ("error", "warning", "information", "hint" or "off")
+	Rules map[string]string `json:"rules"`
+
+	// InputFields and ExpectationFields are the field name patterns compared by the duplicate-inputs rule
+	InputFields       []string `json:"inputFields"`
+	ExpectationFields []string `json:"expectationFields"`
+}
+
+// Find looks for the configuration file in dir and its parents.
+// It returns an empty path if there is none.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, Path)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads and validates a configuration file
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var c Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+	if err := c.ParserOptions().Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// LoadFor finds and loads the configuration that applies to dir.
+// It returns an empty configuration if there is none.
+func LoadFor(dir string) (*Config, error) {
+	path, err := Find(dir)
+	if err != nil || path == "" {
+		return &Config{}, err
+	}
+	return Load(path)
+}
+
+// ParserOptions returns the parser options for the configuration
+func (c *Config) ParserOptions() parser.Options {
+	return parser.Options{
+		NameFields:        c.NameFields,
+		Recognizers:       c.Recognizers,
+		Patterns:          c.Patterns,
+		MaxExpansions:     c.MaxExpansions,
+		GroupDelimiter:    c.GroupDelimiter,
+		GroupFields:       c.GroupFields,
+		GroupTables:       c.GroupTables,
+		AllTables:         c.AllTables,
+		Rules:             c.Lint.Rules,
+		InputFields:       c.Lint.InputFields,
+		ExpectationFields: c.Lint.ExpectationFields,
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFor(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeConfig(t, root, `{"lint": {"rules": {"unused-table": "off", "name-whitespace": "error"}}}`)
+	sub := filepath.Join(root, "pkg", "sub")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadFor(sub)
+	if err != nil {
+		t.Fatalf("LoadFor() error = %v", err)
+	}
+	want := &Config{Lint: Lint{Rules: map[string]string{"unused-table": "off", "name-whitespace": "error"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadFor() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "empty object", content: `{}`},
+		{name: "valid rules", content: `{"lint": {"rules": {"empty-name": "hint"}}}`},
+		{name: "unknown rule", content: `{"lint": {"rules": {"no-such-rule": "off"}}}`, wantErr: true},
+		{name: "unknown severity", content: `{"lint": {"rules": {"empty-name": "fatal"}}}`, wantErr: true},
+		{name: "field patterns", content: `{"lint": {"inputFields": ["in*"], "expectationFields": ["want*", "exp*"]}}`},
+		{name: "malformed field pattern", content: `{"lint": {"expectationFields": ["want["]}}`, wantErr: true},
+		{name: "name fields and recognizers", content: `{"nameFields": ["label"], "recognizers": ["slice", "map"]}`},
+		{name: "patterns", content: `{"patterns": [{"kind": "call", "func": "runCases", "arg": 1}], "recognizers": ["slice", "call:runCases"]}`},
+		{name: "unknown pattern kind", content: `{"patterns": [{"kind": "method", "func": "run"}]}`, wantErr: true},
+		{name: "max expansions", content: `{"maxExpansions": 20}`},
+		{name: "all tables", content: `{"allTables": true}`},
+		{name: "negative max expansions", content: `{"maxExpansions": -1}`, wantErr: true},
+		{name: "grouping", content: `{"groupDelimiter": ": ", "groupFields": ["category"], "groupTables": true}`},
+		{name: "unknown recognizer", content: `{"recognizers": ["struct"]}`, wantErr: true},
+		{name: "unknown field", content: `{"lnt": {}}`, wantErr: true},
+		{name: "malformed", content: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeConfig(t, t.TempDir(), tt.content)
+			if _, err := Load(path); (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package git reads file versions and changed files from a local git repository.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// run executes a git command in dir and returns its standard output
+func run(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// TopLevel returns the root directory of the working tree containing dir
+func TopLevel(dir string) (string, error) {
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ChangedFiles lists the files matching pathspec that were added or modified between base and head,
+// relative to the top level of the working tree.
+// If head is empty, the working tree (including untracked files) is compared against base.
+func ChangedFiles(topLevel, base, head, pathspec string) ([]string, error) {
+	args := []string{"diff", "--name-only", "--no-renames", "--diff-filter=AM", base}
+	if head != "" {
+		args = append(args, head)
+	}
+	out, err := run(topLevel, append(args, "--", pathspec)...)
+	if err != nil {
+		return nil, err
+	}
+	files := lines(out)
+
+	if head == "" {
+		out, err := run(topLevel, "ls-files", "--others", "--exclude-standard", "--", pathspec)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, lines(out)...)
+	}
+	return files, nil
+}
+
+// ErrNotExist is returned by Show when the file does not exist in the revision
+var ErrNotExist = errors.New("file does not exist in revision")
+
+// Show returns the content of path (relative to the top level of the working tree) at rev
+func Show(topLevel, rev, path string) ([]byte, error) {
+	if _, err := run(topLevel, "cat-file", "-e", rev+":"+path); err != nil {
+		if _, revErr := run(topLevel, "rev-parse", "--verify", "--quiet", rev+"^{commit}"); revErr != nil {
+			return nil, revErr
+		}
+		return nil, ErrNotExist
+	}
+	return run(topLevel, "show", rev+":"+path)
+}
+
+func lines(out []byte) []string {
+	var result []string
+	for line := range strings.SplitSeq(string(out), "\n") {
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
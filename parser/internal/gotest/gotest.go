@@ -0,0 +1,238 @@
+// Package gotest runs go test and interprets its JSON event stream.
+package gotest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Event is a single event emitted by go test -json (see go doc cmd/test2json)
+type Event struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"` // seconds
+	Output  string    `json:"Output"`
+}
+
+// Options configures a go test invocation
+type Options struct {
+	Dir     string // directory of the package to test
+	Run     string // -run pattern
+	Count   int    // -count value, 0 means go test's default
+	Race    bool   // enable -race
+	Shuffle string // -shuffle value ("on", "off" or a seed), empty means go test's default
+}
+
+// Args returns the go command arguments for the options
+func (o Options) Args() []string {
+	args := []string{"test", "-json"}
+	if o.Run != "" {
+		args = append(args, "-run", o.Run)
+	}
+	if o.Count > 0 {
+		args = append(args, "-count", strconv.Itoa(o.Count))
+	}
+	if o.Race {
+		args = append(args, "-race")
+	}
+	if o.Shuffle != "" {
+		args = append(args, "-shuffle", o.Shuffle)
+	}
+	return append(args, ".")
+}
+
+// Run executes go test with the given options and returns its events.
+// Failing tests are reported through the events, not as an error;
+// an error is returned only when go test could not run any test (e.g. a build failure).
+func Run(ctx context.Context, opts Options) ([]Event, error) {
+	cmd := exec.CommandContext(ctx, "go", opts.Args()...)
+	cmd.Dir = opts.Dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	events, err := ReadEvents(&stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	if runErr != nil && !hasTestEvents(events) {
+		var exitErr *exec.ExitError
+		if errors.As(runErr, &exitErr) {
+			return nil, fmt.Errorf("go test failed: %w\n%s%s", runErr, buildOutput(events), stderr.String())
+		}
+		return nil, fmt.Errorf("failed to run go test: %w", runErr)
+	}
+
+	return events, nil
+}
+
+// ReadEvents decodes a go test -json event stream
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			// go test may interleave non-JSON lines (e.g. from the build)
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, fmt.Errorf("failed to decode test event: %w", err)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read test events: %w", err)
+	}
+	return events, nil
+}
+
+func hasTestEvents(events []Event) bool {
+	for _, ev := range events {
+		if ev.Test != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func buildOutput(events []Event) string {
+	var b bytes.Buffer
+	for _, ev := range events {
+		if ev.Action == "build-output" || (ev.Action == "output" && ev.Test == "") {
+			b.WriteString(ev.Output)
+		}
+	}
+	return b.String()
+}
+
+// Summary aggregates the outcomes of repeated runs of a single test
+type Summary struct {
+	Test     string
+	Runs     int // number of completed runs (passed or failed)
+	Failures int
+	Skips    int
+	Elapsed  []float64 // elapsed seconds of each completed run
+}
+
+// Summarize aggregates the outcome events per test, keyed by the full test name.
+func Summarize(events []Event) map[string]*Summary {
+	summaries := map[string]*Summary{}
+	for _, ev := range events {
+		if ev.Test == "" {
+			continue
+		}
+		switch ev.Action {
+		case "pass", "fail", "skip":
+		default:
+			continue
+		}
+
+		s, ok := summaries[ev.Test]
+		if !ok {
+			s = &Summary{Test: ev.Test}
+			summaries[ev.Test] = s
+		}
+		if ev.Action == "skip" {
+			s.Skips++
+			continue
+		}
+		s.Runs++
+		if ev.Action == "fail" {
+			s.Failures++
+		}
+		s.Elapsed = append(s.Elapsed, ev.Elapsed)
+	}
+	return summaries
+}
+
+// FailureRate returns the ratio of failed runs to completed runs
+func (s *Summary) FailureRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Failures) / float64(s.Runs)
+}
+
+// Mean returns the mean elapsed time in seconds
+func (s *Summary) Mean() float64 {
+	if len(s.Elapsed) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, e := range s.Elapsed {
+		sum += e
+	}
+	return sum / float64(len(s.Elapsed))
+}
+
+// Variance returns the population variance of the elapsed times
+func (s *Summary) Variance() float64 {
+	if len(s.Elapsed) == 0 {
+		return 0
+	}
+	mean := s.Mean()
+	var sum float64
+	for _, e := range s.Elapsed {
+		sum += (e - mean) * (e - mean)
+	}
+	return sum / float64(len(s.Elapsed))
+}
+
+// StdDev returns the standard deviation of the elapsed times
+func (s *Summary) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Min returns the shortest elapsed time in seconds
+func (s *Summary) Min() float64 {
+	if len(s.Elapsed) == 0 {
+		return 0
+	}
+	return slices.Min(s.Elapsed)
+}
+
+// Max returns the longest elapsed time in seconds
+func (s *Summary) Max() float64 {
+	if len(s.Elapsed) == 0 {
+		return 0
+	}
+	return slices.Max(s.Elapsed)
+}
+
+// FindModuleRoot returns the nearest directory containing a go.mod file, starting at dir
+func FindModuleRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
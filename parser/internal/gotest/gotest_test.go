@@ -0,0 +1,87 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		stream string
+		want   map[string]*Summary
+	}{
+		{
+			name: "repeated runs",
+			stream: `{"Action":"run","Test":"TestExample/a"}
+{"Action":"pass","Test":"TestExample/a","Elapsed":0.1}
+{"Action":"run","Test":"TestExample/a"}
+{"Action":"fail","Test":"TestExample/a","Elapsed":0.3}
+{"Action":"pass","Test":"TestExample","Elapsed":0.4}
+`,
+			want: map[string]*Summary{
+				"TestExample/a": {Test: "TestExample/a", Runs: 2, Failures: 1, Elapsed: []float64{0.1, 0.3}},
+				"TestExample":   {Test: "TestExample", Runs: 1, Elapsed: []float64{0.4}},
+			},
+		},
+		{
+			name: "skipped runs are not completed runs",
+			stream: `{"Action":"skip","Test":"TestExample/a","Elapsed":0}
+{"Action":"pass","Test":"TestExample/a","Elapsed":0.2}
+`,
+			want: map[string]*Summary{
+				"TestExample/a": {Test: "TestExample/a", Runs: 1, Skips: 1, Elapsed: []float64{0.2}},
+			},
+		},
+		{
+			name: "package events and non-JSON lines are ignored",
+			stream: `# example.com/pkg
+{"Action":"output","Output":"ok\n"}
+{"Action":"pass","Elapsed":1}
+`,
+			want: map[string]*Summary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			events, err := ReadEvents(strings.NewReader(tt.stream))
+			if err != nil {
+				t.Fatalf("ReadEvents() error = %v", err)
+			}
+
+			got := Summarize(events)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Summarize() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummaryStats(t *testing.T) {
+	t.Parallel()
+
+	s := &Summary{Runs: 4, Failures: 1, Elapsed: []float64{1, 2, 3, 4}}
+
+	if got, want := s.FailureRate(), 0.25; got != want {
+		t.Errorf("FailureRate() = %v, want %v", got, want)
+	}
+	if got, want := s.Mean(), 2.5; got != want {
+		t.Errorf("Mean() = %v, want %v", got, want)
+	}
+	if got, want := s.Variance(), 1.25; got != want {
+		t.Errorf("Variance() = %v, want %v", got, want)
+	}
+	if got, want := s.Min(), 1.0; got != want {
+		t.Errorf("Min() = %v, want %v", got, want)
+	}
+	if got, want := s.Max(), 4.0; got != want {
+		t.Errorf("Max() = %v, want %v", got, want)
+	}
+}
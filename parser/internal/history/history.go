@@ -0,0 +1,225 @@
+// Package history stores and analyzes per-case elapsed times of past test runs.
+package history
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Path is the location of the history file relative to the module root
+const Path = ".tdt-outline/history.jsonl"
+
+// Record is a single elapsed time measurement of a test case
+type Record struct {
+	Time    time.Time `json:"time"`
+	File    string    `json:"file"` // slash-separated path relative to the module root
+	Test    string    `json:"test"` // full go test name
+	Name    string    `json:"name"` // test case name as written in the source
+	Action  string    `json:"action"`
+	Elapsed float64   `json:"elapsed"` // seconds
+}
+
+// Key identifies a test case across runs
+type Key struct {
+	File string `json:"file"`
+	Test string `json:"test"`
+}
+
+// Key returns the key of the test case the record belongs to
+func (r Record) Key() Key {
+	return Key{File: r.File, Test: r.Test}
+}
+
+// Append appends records to the history file of the module rooted at root
+func Append(root string, records []Record) error {
+	path := filepath.Join(root, Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+
+	enc := json.NewEncoder(f)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			_ = f.Close() // ignore error
+			return fmt.Errorf("failed to write history: %w", err)
+		}
+	}
+	return f.Close()
+}
+
+// Load reads all records from the history file of the module rooted at root.
+// A missing history file is not an error.
+func Load(root string) ([]Record, error) {
+	f, err := os.Open(filepath.Join(root, Path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer func() {
+		_ = f.Close() // ignore error
+	}()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("failed to decode history line %d: %w", line, err)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return records, nil
+}
+
+// Point is a single measurement in a trend
+type Point struct {
+	Time    time.Time `json:"time"`
+	Elapsed float64   `json:"elapsed"`
+}
+
+// Trend is the elapsed time history of a single test case
+type Trend struct {
+	Key
+	Name   string  `json:"name"`
+	Runs   int     `json:"runs"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Latest float64 `json:"latest"`
+	Points []Point `json:"points,omitempty"` // in chronological order
+}
+
+// Trends groups records by test case, ordered by file and test name
+func Trends(records []Record) []Trend {
+	byKey := map[Key]*Trend{}
+	for _, r := range records {
+		t, ok := byKey[r.Key()]
+		if !ok {
+			t = &Trend{Key: r.Key()}
+			byKey[r.Key()] = t
+		}
+		t.Name = r.Name
+		t.Points = append(t.Points, Point{Time: r.Time, Elapsed: r.Elapsed})
+	}
+
+	trends := make([]Trend, 0, len(byKey))
+	for _, t := range byKey {
+		slices.SortStableFunc(t.Points, func(a, b Point) int {
+			return a.Time.Compare(b.Time)
+		})
+		elapsed := elapsedOf(t.Points)
+		t.Runs = len(elapsed)
+		t.Mean = mean(elapsed)
+		t.Median = median(elapsed)
+		t.Latest = elapsed[len(elapsed)-1]
+		trends = append(trends, *t)
+	}
+	slices.SortFunc(trends, func(a, b Trend) int {
+		return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Test, b.Test))
+	})
+	return trends
+}
+
+// Slowest returns the n test cases with the highest mean elapsed time, without their points
+func Slowest(trends []Trend, n int) []Trend {
+	sorted := slices.Clone(trends)
+	slices.SortStableFunc(sorted, func(a, b Trend) int {
+		return cmp.Compare(b.Mean, a.Mean)
+	})
+	sorted = sorted[:min(n, len(sorted))]
+	for i := range sorted {
+		sorted[i].Points = nil
+	}
+	return sorted
+}
+
+// Regression reports a test case whose latest run is slower than its recent median
+type Regression struct {
+	Key
+	Name   string  `json:"name"`
+	Latest float64 `json:"latest"`
+	Median float64 `json:"median"` // median of the runs preceding the latest one
+	Ratio  float64 `json:"ratio"`  // latest / median, 0 if the median is 0
+}
+
+// Regressions reports test cases whose latest elapsed time exceeds the median of the
+// preceding window runs by the given factor and by at least minDelta seconds.
+func Regressions(trends []Trend, window int, factor, minDelta float64) []Regression {
+	var regressions []Regression
+	for _, t := range trends {
+		if len(t.Points) < 2 {
+			continue
+		}
+		elapsed := elapsedOf(t.Points)
+		latest := elapsed[len(elapsed)-1]
+		previous := elapsed[max(0, len(elapsed)-1-window) : len(elapsed)-1]
+		med := median(previous)
+		if latest-med < minDelta || latest < med*factor {
+			continue
+		}
+
+		var ratio float64
+		if med > 0 {
+			ratio = latest / med
+		}
+		regressions = append(regressions, Regression{
+			Key:    t.Key,
+			Name:   t.Name,
+			Latest: latest,
+			Median: med,
+			Ratio:  ratio,
+		})
+	}
+	return regressions
+}
+
+func elapsedOf(points []Point) []float64 {
+	elapsed := make([]float64, len(points))
+	for i, p := range points {
+		elapsed[i] = p.Elapsed
+	}
+	return elapsed
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Sorted(slices.Values(values))
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
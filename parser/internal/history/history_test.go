@@ -0,0 +1,131 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func records(test string, elapsed ...float64) []Record {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var rs []Record
+	for i, e := range elapsed {
+		rs = append(rs, Record{
+			Time:    base.Add(time.Duration(i) * time.Minute),
+			File:    "pkg/example_test.go",
+			Test:    test,
+			Name:    test,
+			Action:  "pass",
+			Elapsed: e,
+		})
+	}
+	return rs
+}
+
+func TestAppendLoad(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	want := records("TestExample/a", 0.1, 0.2)
+
+	if err := Append(root, want[:1]); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := Append(root, want[1:]); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	got, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	got, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("Load() = %v, want nil", got)
+	}
+}
+
+func TestSlowest(t *testing.T) {
+	t.Parallel()
+
+	var rs []Record
+	rs = append(rs, records("TestExample/fast", 0.1, 0.1)...)
+	rs = append(rs, records("TestExample/slow", 2, 4)...)
+	rs = append(rs, records("TestExample/medium", 1, 1, 1)...)
+
+	got := Slowest(Trends(rs), 2)
+	want := []Trend{
+		{Key: Key{File: "pkg/example_test.go", Test: "TestExample/slow"}, Name: "TestExample/slow", Runs: 2, Mean: 3, Median: 3, Latest: 4},
+		{Key: Key{File: "pkg/example_test.go", Test: "TestExample/medium"}, Name: "TestExample/medium", Runs: 3, Mean: 1, Median: 1, Latest: 1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Slowest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		records []Record
+		window  int
+		want    []Regression
+	}{
+		{
+			name:    "latest run is slower than the median",
+			records: records("TestExample/a", 1, 1.2, 1, 3),
+			window:  10,
+			want: []Regression{
+				{Key: Key{File: "pkg/example_test.go", Test: "TestExample/a"}, Name: "TestExample/a", Latest: 3, Median: 1, Ratio: 3},
+			},
+		},
+		{
+			name:    "stable runs",
+			records: records("TestExample/a", 1, 1.2, 1, 1.1),
+			window:  10,
+			want:    nil,
+		},
+		{
+			name:    "only the window is compared",
+			records: records("TestExample/a", 0.1, 0.1, 3, 3, 3),
+			window:  2,
+			want:    nil,
+		},
+		{
+			name:    "slowdowns below the minimum delta are ignored",
+			records: records("TestExample/a", 0, 0, 0.01),
+			window:  10,
+			want:    nil,
+		},
+		{
+			name:    "single run",
+			records: records("TestExample/a", 3),
+			window:  10,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Regressions(Trends(tt.records), tt.window, 1.5, 0.05)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Regressions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
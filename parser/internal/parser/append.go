@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+)
+
+// extractAppendedCases extracts the test cases appended to a table variable in the body,
+// as in `tests = append(tests, tc{name: ...}, ...)`. Cases appended within an if, switch,
+// select or loop statement are marked as conditional in their detail.
+func (e *extractor) extractAppendedCases(body *ast.BlockStmt, t *table) []Symbol {
+	if _, ok := t.lit.Type.(*ast.MapType); ok || t.ident == nil {
+		return nil
+	}
+	structFields := extractStructFields(t.lit.Type)
+
+	var cases []Symbol
+	var stack []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		elts := appendedElements(n, t.ident.Name)
+		if elts == nil {
+			return true
+		}
+		condition, conditional := appendCondition(stack)
+		for _, elt := range elts {
+			testCase, ok := e.extractSliceElement(elt, structFields)
+			if !ok {
+				continue
+			}
+			if conditional {
+				testCase.Detail = "conditional test case"
+				if condition != nil {
+					testCase.Detail += " (if " + printExpr(condition, e.fset) + ")"
+				}
+			}
+			cases = append(cases, testCase)
+		}
+		return true
+	})
+	return cases
+}
+
+// appendedElements returns the elements appended to the named variable if n assigns to it
+// the result of an append to it, as in `tests = append(tests, a, b)` or `tests = append(tests, []tc{a, b}...)`
+func appendedElements(n ast.Node, name string) []ast.Expr {
+	assign, ok := n.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || !isIdent(assign.Lhs[0], name) {
+		return nil
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || !isIdent(call.Fun, "append") || len(call.Args) < 2 || !isIdent(call.Args[0], name) {
+		return nil
+	}
+
+	elts := call.Args[1:]
+	if call.Ellipsis.IsValid() {
+		compLit, ok := elts[0].(*ast.CompositeLit)
+		if !ok {
+			return []ast.Expr{} // appends a table that is not a literal
+		}
+		elts = compLit.Elts
+	}
+	return elts
+}
+
+// appendCondition reports whether the innermost node of the stack is executed conditionally
+// within its function. It returns the condition of the if statement whose body holds the node
+// directly, or nil for other conditional statements.
+func appendCondition(stack []ast.Node) (ast.Expr, bool) {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.IfStmt:
+			if node.Body == stack[i+1] {
+				return node.Cond, true
+			}
+			return nil, true // else branch
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.ForStmt, *ast.RangeStmt:
+			return nil, true
+		case *ast.FuncLit:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// printExpr returns the source text of an expression as formatted by gofmt
+func printExpr(expr ast.Expr, fset *token.FileSet) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+)
+
+// attachComments attaches to test cases the comments on the lines before them and at the end of
+// their last line, as in `// rejects negative input` above `{-1, 0, true},`. The first paragraph of
+// the comment names a case shown with a placeholder name, and is added to the detail of the other cases.
+func (e *extractor) attachComments(cases []Symbol) {
+	if e.src == nil || e.file == nil {
+		return
+	}
+	for i := range cases {
+		c := &cases[i]
+		c.LeadingComment = e.leadingComment(c.Range.Start.Offset)
+		c.TrailingComment = e.trailingComment(c.Range.End.Offset)
+
+		comment := summary(c.LeadingComment)
+		if comment == "" {
+			comment = summary(c.TrailingComment)
+		}
+		switch {
+		case comment == "":
+		case c.Placeholder:
+			c.Name = comment
+		default:
+			c.Detail += " // " + comment
+		}
+	}
+}
+
+// leadingComment returns the text of the comment group ending on the line before offset
+// and starting a line of its own
+func (e *extractor) leadingComment(offset int) string {
+	for i := len(e.comments) - 1; i >= 0; i-- {
+		g := e.comments[i]
+		start, end := e.position(g.Pos()).Offset, e.position(g.End()).Offset
+		if end > offset {
+			continue
+		}
+		between := e.src[end:offset]
+		lineStart := bytes.LastIndexByte(e.src[:start], '\n') + 1
+		if len(bytes.TrimSpace(between)) == 0 && bytes.Count(between, []byte("\n")) == 1 &&
+			len(bytes.TrimSpace(e.src[lineStart:start])) == 0 {
+			return strings.TrimSpace(g.Text())
+		}
+		return ""
+	}
+	return ""
+}
+
+// trailingComment returns the text of the comment group following offset on the same line,
+// after an optional comma
+func (e *extractor) trailingComment(offset int) string {
+	for _, g := range e.comments {
+		start := e.position(g.Pos()).Offset
+		if start < offset {
+			continue
+		}
+		between := bytes.TrimPrefix(bytes.TrimLeft(e.src[offset:start], " \t"), []byte(","))
+		if len(bytes.Trim(between, " \t")) == 0 {
+			return strings.TrimSpace(g.Text())
+		}
+		return ""
+	}
+	return ""
+}
+
+// summary returns the first paragraph of a comment text on a single line
+func summary(text string) string {
+	paragraph, _, _ := strings.Cut(text, "\n\n")
+	return strings.Join(strings.Fields(paragraph), " ")
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// extractTestCaseFromCall extracts a test case from a table element built by a call, such as
+// ok("name", ...) or newCase("name").With(...). The name is the string literal passed to the
+// parameter that the constructor, defined in the same file, assigns to a test name field.
+func (e *extractor) extractTestCaseFromCall(elt ast.Expr) (Symbol, bool) {
+	call := constructorCall(elt)
+	if call == nil {
+		return Symbol{}, false
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return Symbol{}, false
+	}
+	decl := e.funcs[ident.Name]
+	if decl == nil {
+		return Symbol{}, false
+	}
+
+	params := paramNames(decl)
+	index := e.nameParam(decl, params)
+	if index < 0 || index >= len(call.Args) {
+		return Symbol{}, false
+	}
+	name, ok := extractStringLiteral(call.Args[index])
+	if !ok || name == "" {
+		return Symbol{}, false
+	}
+
+	var fields []Field
+	for i, arg := range call.Args {
+		if i == index || i >= len(params) {
+			continue
+		}
+		fields = append(fields, Field{Name: params[i], Value: formatExpr(arg, e.fset)})
+	}
+	return e.createTestCaseSymbol(name, elt, call.Args[index], fields), true
+}
+
+// constructorCall returns the innermost call of a builder chain like newCase("name").With(...).Skip(),
+// or the call itself for a plain constructor call
+func constructorCall(expr ast.Expr) *ast.CallExpr {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	for {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return call
+		}
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return call
+		}
+		call = inner
+	}
+}
+
+// paramNames returns the parameter names of a function, in order.
+// Unnamed parameters have empty names.
+func paramNames(decl *ast.FuncDecl) []string {
+	var names []string
+	for _, field := range decl.Type.Params.List {
+		if len(field.Names) == 0 {
+			names = append(names, "")
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// nameParam returns the index of the parameter of a constructor that is assigned to a test name
+// field of a returned struct literal, as in `return testCase{name: name, ...}`, or -1 if there is none
+func (e *extractor) nameParam(decl *ast.FuncDecl, params []string) int {
+	if decl.Body == nil {
+		return -1
+	}
+	index := -1
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if index >= 0 {
+			return false
+		}
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok {
+			return true
+		}
+		for _, result := range ret.Results {
+			if unary, ok := result.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+				result = unary.X // &testCase{...}
+			}
+			caseLit, ok := result.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			for _, elt := range caseLit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, isKey := kv.Key.(*ast.Ident)
+				value, isValue := kv.Value.(*ast.Ident)
+				if !isKey || !isValue || !e.isTestNameField(key.Name) {
+					continue
+				}
+				for i, param := range params {
+					if param == value.Name {
+						index = i
+						return false
+					}
+				}
+			}
+		}
+		return true
+	})
+	return index
+}
@@ -0,0 +1,252 @@
+package parser
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"slices"
+)
+
+// Diagnostic represents a problem found in a test table, in VS Code's diagnostic format
+type Diagnostic struct {
+	Range    Range  `json:"range"`
+	Severity int    `json:"severity"` // VS Code's DiagnosticSeverity enumeration
+	Rule     string `json:"rule"`     // ID of the lint rule that reported the problem
+	Message  string `json:"message"`
+	Fixes    []Fix  `json:"fixes,omitempty"` // suggested changes resolving the problem
+}
+
+// Fix is a suggested change that resolves a diagnostic
+type Fix struct {
+	Message string     `json:"message"`
+	Edits   []TextEdit `json:"edits"`
+}
+
+// TextEdit replaces the text in a range; an empty range inserts the text
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// VS Code DiagnosticSeverity constants
+const (
+	SeverityError       = 0 // VS Code's DiagnosticSeverity.Error
+	SeverityWarning     = 1 // VS Code's DiagnosticSeverity.Warning
+	SeverityInformation = 2 // VS Code's DiagnosticSeverity.Information
+	SeverityHint        = 3 // VS Code's DiagnosticSeverity.Hint
+)
+
+// Lint rule IDs
+const (
+	RuleDuplicateName      = "duplicate-name"      // two cases with the same name
+	RuleNameCollision      = "name-collision"      // two names that go test rewrites to the same name
+	RuleEmptyName          = "empty-name"          // a case whose name is an empty string
+	RuleMissingName        = "missing-name"        // a case without a name in a table of named cases
+	RuleNameWhitespace     = "name-whitespace"     // a name with leading or trailing whitespace or newlines
+	RuleInconsistentPrefix = "inconsistent-prefix" // a name without the "prefix: " most names in its table have
+	RuleMixedElements      = "mixed-elements"      // a table mixing keyed and positional struct literals
+	RuleUnusedTable        = "unused-table"        // a table variable that is never ranged over with t.Run or passed to a test helper
+	RuleDuplicateCase      = "duplicate-case"      // a case with the same field values as another case of its table
+	RuleDuplicateInputs    = "duplicate-inputs"    // a case with the same inputs but other expectations as another case
+)
+
+// RuleSyntaxError is the rule ID of syntax errors, which are always reported as errors
+const RuleSyntaxError = "syntax-error"
+
+// DefaultSeverities maps each lint rule ID to its default severity
+var DefaultSeverities = map[string]int{
+	RuleDuplicateName:      SeverityWarning,
+	RuleNameCollision:      SeverityWarning,
+	RuleEmptyName:          SeverityWarning,
+	RuleMissingName:        SeverityWarning,
+	RuleNameWhitespace:     SeverityWarning,
+	RuleInconsistentPrefix: SeverityInformation,
+	RuleMixedElements:      SeverityInformation,
+	RuleUnusedTable:        SeverityWarning,
+	RuleDuplicateCase:      SeverityWarning,
+	RuleDuplicateInputs:    SeverityInformation,
+}
+
+// Severity names accepted in Options.Rules
+var severityNames = map[string]int{
+	"error":       SeverityError,
+	"warning":     SeverityWarning,
+	"information": SeverityInformation,
+	"hint":        SeverityHint,
+}
+
+// SeverityOff disables a rule in Options.Rules
+const SeverityOff = "off"
+
+// ValidateRules checks that rule settings refer to known rules and severities
+func ValidateRules(rules map[string]string) error {
+	for rule, severity := range rules {
+		if _, ok := DefaultSeverities[rule]; !ok {
+			return fmt.Errorf("unknown lint rule: %q", rule)
+		}
+		if _, ok := severityNames[severity]; !ok && severity != SeverityOff {
+			return fmt.Errorf("unknown severity for lint rule %q: %q", rule, severity)
+		}
+	}
+	return nil
+}
+
+// report records a diagnostic for a rule unless the rule is disabled.
+// It returns the recorded diagnostic so that fixes can be attached, or nil if the rule is disabled.
+func (e *extractor) report(rule string, r Range, format string, args ...any) *Diagnostic {
+	severity := DefaultSeverities[rule]
+	if setting, ok := e.opts.Rules[rule]; ok {
+		if setting == SeverityOff {
+			return nil
+		}
+		if s, ok := severityNames[setting]; ok {
+			severity = s
+		}
+	}
+
+	e.diagnostics = append(e.diagnostics, Diagnostic{
+		Range:    r,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+	return &e.diagnostics[len(e.diagnostics)-1]
+}
+
+// reportSyntaxError records a syntax error of file as an empty range at its position
+func (e *extractor) reportSyntaxError(file *ast.File, err *scanner.Error) {
+	tf := e.fset.File(file.FileStart)
+	if tf == nil || err.Pos.Offset > tf.Size() {
+		return
+	}
+	pos := e.position(tf.Pos(err.Pos.Offset))
+	e.syntaxErrors = append(e.syntaxErrors, err.Pos.Offset)
+	e.diagnostics = append(e.diagnostics, Diagnostic{
+		Range:    Range{Start: pos, End: pos},
+		Severity: SeverityError,
+		Rule:     RuleSyntaxError,
+		Message:  err.Msg,
+	})
+}
+
+// nodeRange returns the range of a syntax node. Nodes of a partial syntax tree that lack their
+// closing token end at the end of their last child with a known position.
+func (e *extractor) nodeRange(node ast.Node) Range {
+	end := node.End()
+	if !e.validEnd(node, end) {
+		end = e.lastEnd(node)
+	}
+	return Range{Start: e.position(node.Pos()), End: e.position(end)}
+}
+
+// validEnd reports whether end is a known position after the start of node in the same file
+func (e *extractor) validEnd(node ast.Node, end token.Pos) bool {
+	tf := e.fset.File(node.Pos())
+	return tf != nil && end.IsValid() && end >= node.Pos() && end <= token.Pos(tf.Base()+tf.Size())
+}
+
+// lastEnd returns the greatest valid end position of the descendants of node
+func (e *extractor) lastEnd(node ast.Node) token.Pos {
+	end := node.Pos()
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n != nil && n.End() > end && e.validEnd(node, n.End()) {
+			end = n.End()
+		}
+		return true
+	})
+	return end
+}
+
+// hasSyntaxError reports whether a syntax error was reported within node
+func (e *extractor) hasSyntaxError(node ast.Node) bool {
+	r := e.nodeRange(node)
+	for _, offset := range e.syntaxErrors {
+		if offset >= r.Start.Offset && offset <= r.End.Offset {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTestCaseNames reports test cases of a test function that go test cannot tell apart:
+// exact duplicates and names that collide after go test rewrites them.
+// Such cases get a "#01" suffix at run time, so -run patterns built from their names select the wrong case.
+func (e *extractor) checkTestCaseNames(fn Symbol) {
+	cases := testCaseSymbols(fn.Children)
+	names := make([]string, len(cases))
+	for i, c := range cases {
+		names[i] = c.Name
+	}
+	testNames := SubtestNames(names)
+
+	first := map[string]string{} // rewritten name -> first name written in the source
+	for i, c := range cases {
+		if c.Placeholder {
+			continue // not the name go test gives the case
+		}
+		rewritten := SubtestName(c.Name)
+		prev, seen := first[rewritten]
+		if !seen {
+			first[rewritten] = c.Name
+			continue
+		}
+
+		if prev == c.Name {
+			e.report(RuleDuplicateName, c.Range, "duplicate test case name %q; go test runs it as %q", c.Name, testNames[i])
+		} else {
+			e.report(RuleNameCollision, c.Range, "test case name %q collides with %q after go test normalization; go test runs it as %q", c.Name, prev, testNames[i])
+		}
+	}
+}
+
+// sortDiagnostics orders diagnostics by position
+func sortDiagnostics(diagnostics []Diagnostic) {
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(a.Range.Start.Line, b.Range.Start.Line),
+			cmp.Compare(a.Range.Start.Character, b.Range.Start.Character),
+		)
+	})
+}
+
+// ParseError is returned by Analyze in strict mode for a file with syntax errors
+type ParseError struct {
+	Filename string
+	Errors   []Diagnostic // syntax errors, the first of each line
+}
+
+// newParseError returns a ParseError with the syntax errors among diagnostics
+func newParseError(filename string, diagnostics []Diagnostic) *ParseError {
+	err := &ParseError{Filename: filename}
+	for _, d := range diagnostics {
+		if d.Rule == RuleSyntaxError {
+			err.Errors = append(err.Errors, d)
+		}
+	}
+	return err
+}
+
+// Pos returns the position of the first syntax error
+func (e *ParseError) Pos() Line {
+	if len(e.Errors) == 0 {
+		return Line{}
+	}
+	return e.Errors[0].Range.Start
+}
+
+func (e *ParseError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("%s: syntax error", e.Filename)
+	}
+	pos := e.Pos()
+	msg := fmt.Sprintf("%s:%d:%d: %s", e.Filename, pos.Line+1, pos.Character+1, e.Errors[0].Message)
+	switch n := len(e.Errors) - 1; {
+	case n == 1:
+		msg += " (and 1 more error)"
+	case n > 1:
+		msg += fmt.Sprintf(" (and %d more errors)", n)
+	}
+	return msg
+}
@@ -0,0 +1,362 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxExpansions is the number of names generated per subtest by default (see Options.MaxExpansions)
+const DefaultMaxExpansions = 100
+
+// loopRecognizer finds subtests whose names are computed from values ranged over in literals, as in
+// `for _, n := range []int{10, 100} { b.Run(fmt.Sprintf("size=%d", n), ...) }`, with one test case per name
+type loopRecognizer struct{}
+
+func (loopRecognizer) Name() string {
+	return RecognizerLoop
+}
+
+func (loopRecognizer) Recognize(fn *TestFunc) []Symbol {
+	e := fn.e
+	literals := localLiterals(fn.Decl.Body)
+
+	var cases []Symbol
+	var stack []ast.Node
+	ast.Inspect(fn.Decl.Body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isRunCall(call) {
+			return true
+		}
+		loops, ok := enclosingLoops(stack, literals, e.maxExpansions())
+		if !ok {
+			return true
+		}
+		for _, name := range e.expandName(call.Args[0], loops) {
+			testCase := fn.TestCase(name, call, call.Args[0])
+			testCase.Detail = "generated test case"
+			cases = append(cases, testCase)
+		}
+		return true
+	})
+	return cases
+}
+
+// isRunCall reports whether call is a call to a Run method with a name, as in t.Run(name, f)
+func isRunCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Run" && len(call.Args) > 0
+}
+
+// localLiterals returns the composite literals assigned to variables in body, by variable name,
+// so that loops over variables like `sizes := []int{10, 100}` can be evaluated
+func localLiterals(body *ast.BlockStmt) map[string]*ast.CompositeLit {
+	literals := map[string]*ast.CompositeLit{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+		for i, lhs := range assign.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if compLit, ok := assign.Rhs[i].(*ast.CompositeLit); ok {
+				literals[ident.Name] = compLit
+			} else {
+				delete(literals, ident.Name) // redefined with an unknown value
+			}
+		}
+		return true
+	})
+	return literals
+}
+
+// binding is a set of values of loop variables
+type binding map[string]any
+
+// enclosingLoops returns the values bound by each evaluable range loop enclosing the innermost node
+// of the stack, from the outermost loop. It returns false if the node is within the function
+// literal of another Run call, as the names of nested subtests are not outlined.
+// Loops over integers bind at most limit+1 iterations, enough to tell that the names are capped.
+func enclosingLoops(stack []ast.Node, literals map[string]*ast.CompositeLit, limit int) ([][]binding, bool) {
+	var loops [][]binding
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.CallExpr:
+			if isRunCall(node) {
+				return nil, false
+			}
+		case *ast.RangeStmt:
+			if bindings := rangeBindings(node, literals, limit); bindings != nil {
+				loops = append([][]binding{bindings}, loops...)
+			}
+		}
+	}
+	return loops, len(loops) > 0
+}
+
+// rangeBindings returns the values of the key and value variables in each iteration of a range loop
+// over a literal slice or array of constants or over an integer constant, or nil if they are unknown.
+// A loop over an integer binds at most limit+1 iterations.
+func rangeBindings(rangeStmt *ast.RangeStmt, literals map[string]*ast.CompositeLit, limit int) []binding {
+	key, _ := rangeStmt.Key.(*ast.Ident)
+	value, _ := rangeStmt.Value.(*ast.Ident)
+	bind := func(k, v any) binding {
+		b := binding{}
+		if key != nil && key.Name != "_" {
+			b[key.Name] = k
+		}
+		if value != nil && value.Name != "_" {
+			b[value.Name] = v
+		}
+		return b
+	}
+
+	// Pattern: for i := range 3
+	if n, ok := constValue(rangeStmt.X, ""); ok {
+		count, ok := n.(int64)
+		if !ok {
+			return nil
+		}
+		var bindings []binding
+		for i := range min(count, int64(limit)+1) {
+			bindings = append(bindings, bind(i, nil))
+		}
+		return bindings
+	}
+
+	// Pattern: for _, n := range []int{10, 100}, for _, n := range sizes
+	compLit, ok := rangeStmt.X.(*ast.CompositeLit)
+	if ident, isIdent := rangeStmt.X.(*ast.Ident); isIdent {
+		compLit, ok = literals[ident.Name]
+	}
+	if !ok {
+		return nil
+	}
+	arrayType, ok := compLit.Type.(*ast.ArrayType)
+	if !ok {
+		return nil
+	}
+	// Values of other types, like time.Duration, may be formatted by their String method
+	elemType, ok := arrayType.Elt.(*ast.Ident)
+	if !ok || !basicTypes[elemType.Name] {
+		return nil
+	}
+	var bindings []binding
+	for i, elt := range compLit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); ok {
+			return nil // indexed elements
+		}
+		v, ok := constValue(elt, elemType.Name)
+		if !ok {
+			return nil
+		}
+		bindings = append(bindings, bind(int64(i), v))
+	}
+	return bindings
+}
+
+// basicTypes are the predeclared types whose values are evaluated
+var basicTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// constValue returns the value of a constant expression of a basic type, converted to the named type
+// if given, as an int64, uint64, float64, rune, string or bool
+func constValue(expr ast.Expr, typeName string) (any, bool) {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return constValue(expr.X, typeName)
+	case *ast.Ident:
+		if expr.Name == "true" || expr.Name == "false" {
+			return expr.Name == "true", true
+		}
+	case *ast.UnaryExpr:
+		if expr.Op != token.SUB {
+			return nil, false
+		}
+		if lit, ok := expr.X.(*ast.BasicLit); ok && (lit.Kind == token.INT || lit.Kind == token.FLOAT) {
+			return basicValue(&ast.BasicLit{Kind: lit.Kind, Value: "-" + lit.Value}, typeName)
+		}
+	case *ast.BasicLit:
+		return basicValue(expr, typeName)
+	}
+	return nil, false
+}
+
+// basicValue returns the value of a basic literal, converted to the named type if given
+func basicValue(lit *ast.BasicLit, typeName string) (any, bool) {
+	switch lit.Kind {
+	case token.STRING:
+		s, ok := extractStringLiteral(lit)
+		return s, ok
+	case token.CHAR:
+		s, _, _, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+		if err != nil {
+			return nil, false
+		}
+		if typeName == "byte" {
+			return uint64(s), true
+		}
+		return s, true
+	case token.FLOAT:
+		f, err := strconv.ParseFloat(lit.Value, 64)
+		return f, err == nil
+	case token.INT:
+		switch {
+		case strings.HasPrefix(typeName, "float"):
+			f, err := strconv.ParseFloat(lit.Value, 64)
+			return f, err == nil
+		case strings.HasPrefix(typeName, "uint"), typeName == "byte":
+			u, err := strconv.ParseUint(lit.Value, 0, 64)
+			return u, err == nil
+		}
+		i, err := strconv.ParseInt(lit.Value, 0, 64)
+		return i, err == nil
+	}
+	return nil, false
+}
+
+// expandName evaluates a subtest name expression for each combination of the values of the loops it
+// refers to, up to Options.MaxExpansions names. It returns nil if the name cannot be evaluated.
+func (e *extractor) expandName(expr ast.Expr, loops [][]binding) []string {
+	limit := e.maxExpansions()
+
+	// Only the loops whose variables appear in the name multiply the names
+	used := map[string]bool{}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			used[ident.Name] = true
+		}
+		return true
+	})
+	var relevant [][]binding
+	for _, bindings := range loops {
+		for name := range bindings[0] {
+			if used[name] {
+				relevant = append(relevant, bindings)
+				break
+			}
+		}
+	}
+	if len(relevant) == 0 {
+		return nil
+	}
+
+	var names []string
+	var expand func(env binding, depth int) bool
+	expand = func(env binding, depth int) bool {
+		if depth == len(relevant) {
+			name, ok := evalString(expr, env)
+			if !ok {
+				return false
+			}
+			names = append(names, name)
+			return true
+		}
+		for _, b := range relevant[depth] {
+			if len(names) >= limit {
+				return true
+			}
+			next := binding{}
+			for k, v := range env {
+				next[k] = v
+			}
+			for k, v := range b {
+				next[k] = v
+			}
+			if !expand(next, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	if !expand(binding{}, 0) {
+		return nil
+	}
+	return names
+}
+
+// maxExpansions returns the maximum number of names generated per subtest
+func (e *extractor) maxExpansions() int {
+	if e.opts.MaxExpansions == 0 {
+		return DefaultMaxExpansions
+	}
+	return e.opts.MaxExpansions
+}
+
+// evalString evaluates a string expression made of constants, loop variables and calls to
+// fmt.Sprintf, fmt.Sprint and strconv.Itoa
+func evalString(expr ast.Expr, env binding) (string, bool) {
+	v, ok := eval(expr, env)
+	s, isString := v.(string)
+	return s, ok && isString
+}
+
+// eval evaluates an expression made of constants, loop variables and calls to
+// fmt.Sprintf, fmt.Sprint and strconv.Itoa
+func eval(expr ast.Expr, env binding) (any, bool) {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return eval(expr.X, env)
+	case *ast.Ident:
+		if v, ok := env[expr.Name]; ok && v != nil {
+			return v, true
+		}
+		return constValue(expr, "")
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			return nil, false
+		}
+		x, okX := evalString(expr.X, env)
+		y, okY := evalString(expr.Y, env)
+		return x + y, okX && okY
+	case *ast.CallExpr:
+		return evalCall(expr, env)
+	}
+	return constValue(expr, "")
+}
+
+// evalCall evaluates a call to fmt.Sprintf with a constant format, fmt.Sprint or strconv.Itoa
+func evalCall(call *ast.CallExpr, env binding) (any, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return nil, false
+	}
+	args := make([]any, len(call.Args))
+	for i, arg := range call.Args {
+		v, ok := eval(arg, env)
+		if !ok {
+			return nil, false
+		}
+		args[i] = v
+	}
+
+	switch {
+	case isIdent(sel.X, "fmt") && sel.Sel.Name == "Sprintf" && len(args) > 0:
+		format, ok := constValue(call.Args[0], "")
+		if s, isString := format.(string); ok && isString {
+			return fmt.Sprintf(s, args[1:]...), true
+		}
+	case isIdent(sel.X, "fmt") && sel.Sel.Name == "Sprint":
+		return fmt.Sprint(args...), true
+	case isIdent(sel.X, "strconv") && sel.Sel.Name == "Itoa" && len(args) == 1:
+		if i, ok := args[0].(int64); ok {
+			return strconv.FormatInt(i, 10), true
+		}
+	}
+	return nil, false
+}
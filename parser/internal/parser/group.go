@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// groupDetail is the detail of the intermediate symbols grouping test cases
+const groupDetail = "test case group"
+
+// groupCases groups the test cases of a test function by the value of a field of
+// Options.GroupFields and then by the parts of their names split by Options.GroupDelimiter,
+// as ":" groups "error case: invalid input" under "error case". Each group is a symbol spanning
+// its members, placed at its first member. Test cases keep their full names.
+func (e *extractor) groupCases(cases []Symbol) []Symbol {
+	if len(e.opts.GroupFields) == 0 && e.opts.GroupDelimiter == "" {
+		return cases
+	}
+	paths := make([][]string, len(cases))
+	for i, c := range cases {
+		if isTable(c) {
+			cases[i].Children = e.groupCases(c.Children) // tables stay in place
+			continue
+		}
+		paths[i] = e.groupPath(c)
+	}
+	return groupByPath(cases, paths, 0)
+}
+
+// groupPath returns the names of the groups of a test case, from the outermost
+func (e *extractor) groupPath(c Symbol) []string {
+	var path []string
+	if group := e.groupFieldValue(c); group != "" {
+		path = append(path, group)
+	}
+	if e.opts.GroupDelimiter == "" || c.Placeholder {
+		return path
+	}
+	parts := strings.Split(c.Name, e.opts.GroupDelimiter)
+	for _, part := range parts[:len(parts)-1] {
+		if part = strings.TrimSpace(part); part != "" {
+			path = append(path, part)
+		}
+	}
+	return path
+}
+
+// groupFieldValue returns the value of the first field of Options.GroupFields that a test case has,
+// unquoted if it is a string literal
+func (e *extractor) groupFieldValue(c Symbol) string {
+	for _, name := range e.opts.GroupFields {
+		for _, f := range c.Fields {
+			if !strings.EqualFold(f.Name, name) {
+				continue
+			}
+			if s, err := strconv.Unquote(f.Value); err == nil {
+				return s
+			}
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// groupByPath groups the test cases whose paths are longer than depth by their path element at depth
+func groupByPath(cases []Symbol, paths [][]string, depth int) []Symbol {
+	var result []Symbol
+	index := map[string]int{} // group name -> index in result
+	var members [][]int       // indexes of the cases of each group of result, by index in result
+	for i, c := range cases {
+		if len(paths[i]) <= depth {
+			result = append(result, c)
+			members = append(members, nil)
+			continue
+		}
+		name := paths[i][depth]
+		j, ok := index[name]
+		if !ok {
+			j = len(result)
+			index[name] = j
+			result = append(result, Symbol{Name: name, Detail: groupDetail, Kind: SymbolKindNamespace})
+			members = append(members, nil)
+		}
+		members[j] = append(members[j], i)
+	}
+
+	for j, indexes := range members {
+		if indexes == nil {
+			continue
+		}
+		groupCases := make([]Symbol, len(indexes))
+		groupPaths := make([][]string, len(indexes))
+		for k, i := range indexes {
+			groupCases[k] = cases[i]
+			groupPaths[k] = paths[i]
+		}
+		group := &result[j]
+		group.Children = groupByPath(groupCases, groupPaths, depth+1)
+		group.Range = groupCases[0].Range
+		group.SelectionRange = groupCases[0].SelectionRange
+		for _, c := range groupCases[1:] {
+			if c.Range.Start.Offset < group.Range.Start.Offset {
+				group.Range.Start = c.Range.Start
+			}
+			if c.Range.End.Offset > group.Range.End.Offset {
+				group.Range.End = c.Range.End
+			}
+		}
+	}
+	return result
+}
+
+// isGroup reports whether a symbol groups test cases
+func isGroup(s Symbol) bool {
+	return s.Kind == SymbolKindNamespace && s.Detail == groupDetail
+}
+
+// tableDetail is the detail of the intermediate symbols of test tables
+const tableDetail = "test table"
+
+// groupTables places the test cases of each table of a function with several tables under a symbol
+// of the table (see Options.GroupTables), named after its variable or "range #N" for the Nth inline
+// table. The symbol spans the table literal and the cases appended to it. Other test cases stay in place.
+func (e *extractor) groupTables(tables []table, cases []Symbol) []Symbol {
+	tables = slices.DeleteFunc(slices.Clone(tables), func(t table) bool {
+		return len(t.cases) == 0 || !t.outlined // not a test table (e.g. a slice of expected values)
+	})
+	if !e.opts.GroupTables || len(tables) < 2 {
+		return cases
+	}
+
+	symbols := make([]Symbol, len(tables))
+	inline := 0
+	for i, t := range tables {
+		name := ""
+		if t.ident != nil {
+			name = t.ident.Name
+		} else {
+			inline++
+			name = fmt.Sprintf("range #%d", inline)
+		}
+		symbols[i] = Symbol{
+			Name:           name,
+			Detail:         tableDetail,
+			Kind:           SymbolKindVariable,
+			Range:          e.nodeRange(t.lit),
+			SelectionRange: Range{Start: e.position(t.lit.Lbrace), End: e.position(t.lit.Lbrace + 1)},
+		}
+	}
+
+	var result []Symbol
+	at := map[int]int{} // index of a table -> index of its symbol in result, placed at its first case
+	for _, c := range cases {
+		i := slices.IndexFunc(tables, func(t table) bool {
+			return slices.ContainsFunc(t.cases, func(tc Symbol) bool {
+				return tc.Range == c.Range && tc.Name == c.Name
+			})
+		})
+		if i < 0 {
+			result = append(result, c)
+			continue
+		}
+		if _, ok := at[i]; !ok {
+			at[i] = len(result)
+			result = append(result, Symbol{})
+		}
+		symbol := &symbols[i]
+		symbol.Children = append(symbol.Children, c)
+		if c.Range.Start.Offset < symbol.Range.Start.Offset {
+			symbol.Range.Start = c.Range.Start
+		}
+		if c.Range.End.Offset > symbol.Range.End.Offset {
+			symbol.Range.End = c.Range.End
+		}
+	}
+	for i, j := range at {
+		result[j] = symbols[i]
+	}
+	return result
+}
+
+// isTable reports whether a symbol holds the test cases of a table
+func isTable(s Symbol) bool {
+	return s.Kind == SymbolKindVariable && s.Detail == tableDetail
+}
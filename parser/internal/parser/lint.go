@@ -0,0 +1,318 @@
+package parser
+
+import (
+	"go/ast"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// lintTables reports hygiene problems in the test tables of a test function
+func (e *extractor) lintTables(decl *ast.FuncDecl, tables []table) {
+	body := decl.Body
+	for _, t := range tables {
+		e.nameField = t.nameField
+		if len(t.cases) == 0 {
+			// Not a test table (e.g. a slice of expected values)
+			if t.outlined || isAnonymousStructTable(t.lit) {
+				e.lintTableNames(t)
+			}
+			continue
+		}
+		if t.outlined {
+			e.lintTableNames(t)
+			e.lintMixedElements(t)
+			e.lintPrefixes(t)
+			e.lintDuplicateCases(t)
+		}
+		// Literals of named types that are not outlined are taken for data (see recognize)
+		if !t.outlined && !isAnonymousStructTable(t.lit) {
+			continue
+		}
+		if t.ident != nil && !e.hasSyntaxError(body) && !e.isRunTable(decl, t) {
+			e.report(RuleUnusedTable, e.nodeRange(t.ident), "test table %q is never ranged over with t.Run or passed to a test helper", t.ident.Name)
+		}
+	}
+	e.nameField = ""
+}
+
+// lintTableNames reports empty, missing and badly formatted test case names
+func (e *extractor) lintTableNames(t table) {
+	_, isMap := t.lit.Type.(*ast.MapType)
+	structFields := extractStructFields(t.lit.Type)
+	for i, elt := range t.lit.Elts {
+		var name string
+		var nameExpr ast.Expr
+		if isMap {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			var isString bool
+			if name, isString = extractStringLiteral(kv.Key); isString {
+				nameExpr = kv.Key
+			}
+		} else {
+			caseLit, ok := elt.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			name, nameExpr = e.extractTestName(caseLit, structFields)
+		}
+
+		switch {
+		case nameExpr == nil:
+			if t.named && !isMap {
+				r := e.nodeRange(elt)
+				shown := unnamedPlaceholder(i)
+				if j := slices.IndexFunc(t.cases, func(c Symbol) bool { return c.Range == r }); j >= 0 {
+					shown = t.cases[j].Name // e.g. the comment on the case
+				}
+				e.report(RuleMissingName, r, "test case has no name and is shown as %q in the outline", shown)
+			}
+		case name == "":
+			e.report(RuleEmptyName, e.nodeRange(nameExpr), "test case has an empty name; go test names it by its index (#00, #01, ...)")
+		case strings.ContainsAny(name, "\r\n"):
+			if d := e.report(RuleNameWhitespace, e.nodeRange(nameExpr), "test case name %q contains a newline", name); d != nil {
+				fixed := strings.TrimSpace(newlineReplacer.Replace(name))
+				d.Fixes = []Fix{e.replaceFix("Replace newlines with spaces", nameExpr, quoteLike(nameExpr, fixed))}
+			}
+		case strings.TrimSpace(name) != name:
+			if d := e.report(RuleNameWhitespace, e.nodeRange(nameExpr), "test case name %q has leading or trailing whitespace", name); d != nil {
+				d.Fixes = []Fix{e.replaceFix("Trim whitespace", nameExpr, quoteLike(nameExpr, strings.TrimSpace(name)))}
+			}
+		}
+	}
+}
+
+// lintMixedElements reports struct literals whose form (keyed or positional)
+// differs from the first test case of the table
+func (e *extractor) lintMixedElements(t table) {
+	names := keyNames(extractStructFields(t.lit.Type))
+	var firstForm string
+	for _, elt := range t.lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value // map entry
+		}
+		caseLit, ok := elt.(*ast.CompositeLit)
+		if !ok || len(caseLit.Elts) == 0 {
+			continue
+		}
+
+		form := "positional"
+		if _, ok := caseLit.Elts[0].(*ast.KeyValueExpr); ok {
+			form = "keyed"
+		}
+		if firstForm == "" {
+			firstForm = form
+			continue
+		}
+		if form == firstForm {
+			continue
+		}
+		d := e.report(RuleMixedElements, e.nodeRange(caseLit), "%s test case in a table of %s test cases", form, firstForm)
+		if d != nil && form == "positional" && len(names) == len(caseLit.Elts) {
+			fix := Fix{Message: "Convert to keyed fields"}
+			for i, value := range caseLit.Elts {
+				fix.Edits = append(fix.Edits, e.insertEdit(value, names[i]+": "))
+			}
+			d.Fixes = []Fix{fix}
+		}
+	}
+}
+
+// keyNames returns the field names usable as keys in a literal of the struct, in declaration order.
+// It returns nil if the struct has embedded fields, whose keys cannot be derived from syntax alone.
+func keyNames(structFields []*ast.Field) []string {
+	for _, field := range structFields {
+		if len(field.Names) == 0 {
+			return nil
+		}
+	}
+	return fieldNames(structFields)
+}
+
+// lintPrefixes reports test case names without the "prefix: " form used by most names in the table
+func (e *extractor) lintPrefixes(t table) {
+	var example string
+	prefixed := 0
+	cases := slices.DeleteFunc(slices.Clone(t.cases), func(c Symbol) bool {
+		return strings.HasPrefix(c.Detail, unnamedDetail)
+	})
+	for _, c := range cases {
+		if namePrefix(c.Name) != "" {
+			if example == "" {
+				example = c.Name
+			}
+			prefixed++
+		}
+	}
+	if prefixed < 2 || prefixed*2 < len(cases) || prefixed == len(cases) {
+		return
+	}
+
+	for _, c := range cases {
+		if namePrefix(c.Name) == "" {
+			e.report(RuleInconsistentPrefix, c.Range, "test case name %q has no prefix like the other cases in the table (e.g. %q)", c.Name, example)
+		}
+	}
+}
+
+var newlineReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// quoteLike quotes s as a Go string literal, keeping the raw string form of lit when possible
+func quoteLike(lit ast.Expr, s string) string {
+	if basicLit, ok := lit.(*ast.BasicLit); ok && strings.HasPrefix(basicLit.Value, "`") && !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// replaceFix returns a fix replacing node with text
+func (e *extractor) replaceFix(message string, node ast.Node, text string) Fix {
+	return Fix{Message: message, Edits: []TextEdit{{Range: e.nodeRange(node), NewText: text}}}
+}
+
+// insertEdit returns an edit inserting text before node
+func (e *extractor) insertEdit(node ast.Node, text string) TextEdit {
+	start := e.nodeRange(node).Start
+	return TextEdit{Range: Range{Start: start, End: start}, NewText: text}
+}
+
+// lintDuplicateCases reports cases whose field values, other than the name, are the same as
+// those of an earlier case in the table, and cases that only differ in their expectations
+func (e *extractor) lintDuplicateCases(t table) {
+	for i, c := range t.cases {
+		if !comparableFields(c.Fields) {
+			continue
+		}
+		for _, prev := range t.cases[:i] {
+			if !comparableFields(prev.Fields) {
+				continue
+			}
+			differing := differingFields(prev.Fields, c.Fields)
+			if len(differing) == 0 {
+				e.report(RuleDuplicateCase, c.Range, "test case %q has the same field values as %q", c.Name, prev.Name)
+				break
+			}
+			if e.sameInputs(c.Fields, differing) {
+				e.report(RuleDuplicateInputs, c.Range, "test case %q has the same inputs as %q and differs only in %s", c.Name, prev.Name, strings.Join(differing, ", "))
+				break
+			}
+		}
+	}
+}
+
+// comparableFields reports whether the cases with the fields can be compared for duplicates.
+// Cases of simple maps ("one": 1) have a single unnamed value and are not compared.
+func comparableFields(fields []Field) bool {
+	return len(fields) > 0 && fields[0].Name != ""
+}
+
+// differingFields returns the names of the fields whose values differ, in order of appearance.
+// A field missing from one of the cases differs from any value.
+func differingFields(a, b []Field) []string {
+	values := map[string]string{}
+	for _, f := range a {
+		values[f.Name] = f.Value
+	}
+	var differing []string
+	seen := map[string]bool{}
+	for _, f := range b {
+		seen[f.Name] = true
+		if v, ok := values[f.Name]; !ok || v != f.Value {
+			differing = append(differing, f.Name)
+		}
+	}
+	for _, f := range a {
+		if !seen[f.Name] {
+			differing = append(differing, f.Name)
+		}
+	}
+	return differing
+}
+
+// sameInputs reports whether the case has inputs and none of the differing fields is an input
+func (e *extractor) sameInputs(fields []Field, differing []string) bool {
+	hasInput := false
+	for _, f := range fields {
+		if e.isInputField(f.Name) {
+			hasInput = true
+		}
+	}
+	if !hasInput {
+		return false
+	}
+	for _, name := range differing {
+		if e.isInputField(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// isExpectationField reports whether a field holds an expected result
+func (e *extractor) isExpectationField(name string) bool {
+	patterns := e.opts.ExpectationFields
+	if patterns == nil {
+		patterns = DefaultExpectationFields
+	}
+	return matchField(patterns, name)
+}
+
+// isInputField reports whether a field holds an input of the test case
+func (e *extractor) isInputField(name string) bool {
+	if e.isExpectationField(name) {
+		return false
+	}
+	return e.opts.InputFields == nil || matchField(e.opts.InputFields, name)
+}
+
+// matchField reports whether a field name matches one of the patterns, ignoring case
+func matchField(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// namePrefix returns the "prefix" part of names like "prefix: description"
+func namePrefix(name string) string {
+	prefix, _, ok := strings.Cut(name, ": ")
+	if !ok || strings.TrimSpace(prefix) == "" {
+		return ""
+	}
+	return prefix
+}
+
+// isAnonymousStructTable reports whether a table is a slice, array or map of anonymous structs,
+// as in []struct{ name string }{...}
+func isAnonymousStructTable(lit *ast.CompositeLit) bool {
+	switch typ := lit.Type.(type) {
+	case *ast.ArrayType:
+		_, ok := typ.Elt.(*ast.StructType)
+		return ok
+	case *ast.MapType:
+		_, ok := typ.Value.(*ast.StructType)
+		return ok
+	}
+	return false
+}
+
+// callsRun reports whether node contains a call to a Run method (t.Run, b.Run)
+func callsRun(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if call, ok := n.(*ast.CallExpr); ok && isRunCall(call) {
+			found = true
+		}
+		return true
+	})
+	return found
+}
@@ -1,12 +1,19 @@
 package parser
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
+	"go/printer"
+	"go/scanner"
 	"go/token"
+	"go/types"
 	"io"
 	"os"
+	"path"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -14,11 +21,34 @@ import (
 
 // Symbol represents a code symbol in VS Code's outline format
 type Symbol struct {
-	Name     string   `json:"name"`
-	Detail   string   `json:"detail"`
-	Kind     int      `json:"kind"` // VS Code's SymbolKind enumeration
-	Range    Range    `json:"range"`
-	Children []Symbol `json:"children"`
+	Name           string   `json:"name"`
+	Detail         string   `json:"detail"`
+	Kind           int      `json:"kind"` // VS Code's SymbolKind enumeration
+	Range          Range    `json:"range"`
+	SelectionRange Range    `json:"selectionRange"` // range of the function name, name string literal or map key
+	Children       []Symbol `json:"children"`
+
+	// Reference is the range of the declaration of the function a test case runs, for cases of
+	// function-valued tables whose function is declared in the same file
+	Reference *Range `json:"reference,omitempty"`
+
+	// LeadingComment and TrailingComment are the text of the comments on the lines before a test case
+	// and at the end of its last line, without comment markers
+	LeadingComment  string `json:"leadingComment,omitempty"`
+	TrailingComment string `json:"trailingComment,omitempty"`
+
+	// Placeholder reports whether the name of a test case is not the name go test gives it,
+	// but a placeholder like "#2" or the comment of a case without a name
+	Placeholder bool `json:"-"`
+
+	// Fields lists the field values of a test case other than its name
+	Fields []Field `json:"-"`
+}
+
+// Field is a field value of a test case
+type Field struct {
+	Name  string // field name, the element index for positional values of unknown types, or empty for map values that are not struct literals
+	Value string // source text of the value in normalized form (see Normalize)
 }
 
 // Range represents a text range in a file
@@ -30,32 +60,214 @@ type Range struct {
 // Line represents a position in a file (0-indexed)
 type Line struct {
 	Line      int `json:"line"`
-	Character int `json:"character"`
+	Character int `json:"character"` // offset in the line, in units of Options.PositionEncoding
+	Offset    int `json:"offset"`    // byte offset from the start of the file
 }
 
 // VS Code SymbolKind constants
 const (
-	SymbolKindFunction = 11 // VS Code's SymbolKind.Function
-	SymbolKindStruct   = 22 // VS Code's SymbolKind.Struct
+	SymbolKindNamespace = 2  // VS Code's SymbolKind.Namespace
+	SymbolKindFunction  = 11 // VS Code's SymbolKind.Function
+	SymbolKindVariable  = 12 // VS Code's SymbolKind.Variable
+	SymbolKindStruct    = 22 // VS Code's SymbolKind.Struct
 )
 
+// SchemaVersion is the version of the JSON form of Result and Symbol.
+// Fields may be added within a version, but are not removed or changed.
+const SchemaVersion = 1
+
+// Result is the outcome of analyzing a Go source file
+type Result struct {
+	Version     int          `json:"version"` // SchemaVersion
+	Symbols     []Symbol     `json:"symbols"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
 // Parse analyzes Go source code and extracts test functions with their test cases.
 // filename is used for error messages and position information.
 // src is an io.Reader containing Go source code.
+// Syntax errors do not fail parsing: the test cases in the parts of the file that parsed are returned.
 func Parse(filename string, src io.Reader) ([]Symbol, error) {
+	result, err := Analyze(filename, src, Options{})
+	if err != nil {
+		return nil, err
+	}
+	return result.Symbols, nil
+}
+
+// ParseFile analyzes a Go file and extracts test functions with their test cases.
+func ParseFile(filePath string) ([]Symbol, error) {
+	result, err := AnalyzeFile(filePath, Options{})
+	if err != nil {
+		return nil, err
+	}
+	return result.Symbols, nil
+}
+
+// Options configures the analysis. The zero value recognizes every supported table pattern and
+// enables every lint rule with its default severity.
+type Options struct {
+	// NameFields are the struct field names holding test case names, matched case-insensitively.
+	// Nil means DefaultNameFields.
+	NameFields []string `json:"nameFields,omitempty"`
+
+	// Recognizers lists the names of the enabled recognizers (RecognizerSlice, RecognizerMap, ...
+	// or the names of Patterns and CustomRecognizers). Nil enables all of them.
+	Recognizers []string `json:"recognizers,omitempty"`
+
+	// Patterns are user-defined test case patterns, recognized alongside the built-in ones
+	Patterns []Pattern `json:"patterns,omitempty"`
+
+	// CustomRecognizers find test cases in patterns other than the built-in ones
+	CustomRecognizers []Recognizer `json:"-"`
+
+	// TypeInfo is the type information of the package, if available, passed to recognizers
+	TypeInfo *types.Info `json:"-"`
+
+	// Rules overrides the severity of lint rules by rule ID.
+	// Values are "error", "warning", "information", "hint", or "off" to disable the rule.
+	Rules map[string]string `json:"rules,omitempty"`
+
+	// InputFields and ExpectationFields are path.Match patterns of the field names compared by the
+	// duplicate-inputs rule, matched case-insensitively. Fields matching ExpectationFields
+	// (DefaultExpectationFields if nil) are expectations; the others are inputs, restricted to the
+	// fields matching InputFields if it is set.
+	InputFields       []string `json:"inputFields,omitempty"`
+	ExpectationFields []string `json:"expectationFields,omitempty"`
+
+	// PositionEncoding is the unit of Line.Character: EncodingUTF8, EncodingUTF16 or EncodingUTF32.
+	// Empty means DefaultPositionEncoding.
+	PositionEncoding string `json:"positionEncoding,omitempty"`
+
+	// MaxExpansions is the maximum number of test cases generated from a subtest whose name is
+	// computed over literal ranges (see RecognizerLoop). Zero means DefaultMaxExpansions.
+	MaxExpansions int `json:"maxExpansions,omitempty"`
+
+	// GroupDelimiter splits test case names into nested groups, shown as intermediate symbols,
+	// as ":" groups "error case: invalid input" under "error case". Empty disables the grouping by name.
+	GroupDelimiter string `json:"groupDelimiter,omitempty"`
+
+	// GroupFields are the struct field names whose values group test cases, matched case-insensitively
+	// in order of preference, such as "group" or "category". Cases are grouped by field before by name.
+	GroupFields []string `json:"groupFields,omitempty"`
+
+	// GroupTables places the test cases of each table of a function with several tables under an
+	// intermediate symbol named after the table variable, or "range #N" for the Nth inline table.
+	GroupTables bool `json:"groupTables,omitempty"`
+
+	// PackageDir is the directory of the package, whose other Go files are searched for the test
+	// helpers tables are passed to. AnalyzeFile defaults it to the directory of the file.
+	PackageDir string `json:"-"`
+
+	// AllTables outlines the test cases of every table literal of a test function. By default, only
+	// the tables ranged over (or indexed) in a loop calling t.Run, b.Run or a known runner, or passed
+	// to a helper along with a *testing.T or to the function of a pattern, are outlined, so that
+	// literals like `want := []Item{{Name: "x"}}` are not taken for tables. Known runners are the
+	// functions of Patterns and the functions of the package that take a *testing.T and call Run.
+	AllTables bool `json:"allTables,omitempty"`
+
+	// Strict makes Analyze fail with a *ParseError on syntax errors
+	// instead of outlining the parts of the file that parse.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// Names of the built-in recognizers
+const (
+	RecognizerSlice       = "slice"        // slices of structs, e.g. tests := []struct{...}{...}
+	RecognizerMap         = "map"          // maps keyed by test case name, e.g. tests := map[string]struct{...}{...}
+	RecognizerRangeInline = "range-inline" // tables ranged over directly, e.g. for _, tt := range []struct{...}{...}
+	RecognizerVarDecl     = "var-decl"     // tables declared with var, e.g. var tests = []struct{...}{...}
+	RecognizerRunner      = "runner"       // tables passed to helpers taking *testing.T, e.g. runTableTests(t, []struct{...}{...})
+	RecognizerLoop        = "loop"         // names computed over literal ranges, e.g. for _, n := range []int{1, 2} { t.Run(fmt.Sprint(n), ...) }
+)
+
+// AllRecognizers lists the names of the built-in recognizers
+var AllRecognizers = []string{RecognizerSlice, RecognizerMap, RecognizerRangeInline, RecognizerVarDecl, RecognizerRunner, RecognizerLoop}
+
+// DefaultExpectationFields are the field name patterns treated as expectations by default
+var DefaultExpectationFields = []string{"want*", "expect*"}
+
+// Validate checks that the options refer to known rules and severities and hold valid field patterns
+func (o Options) Validate() error {
+	if err := ValidateRules(o.Rules); err != nil {
+		return err
+	}
+	if err := validateEncoding(o.PositionEncoding); err != nil {
+		return err
+	}
+	if err := o.validateRecognizers(); err != nil {
+		return err
+	}
+	if o.MaxExpansions < 0 {
+		return fmt.Errorf("invalid maximum number of expansions: %d", o.MaxExpansions)
+	}
+	for _, pattern := range slices.Concat(o.InputFields, o.ExpectationFields) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid field pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Analyze is like Parse but also reports diagnostics on the detected test cases,
+// including the syntax errors of the file.
+func Analyze(filename string, src io.Reader, opts Options) (*Result, error) {
 	if filename == "" {
 		return nil, fmt.Errorf("filename cannot be empty")
 	}
 
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filename, src, 0)
+	data, err := io.ReadAll(src)
 	if err != nil {
+		return nil, fmt.Errorf("failed to read Go file %s: %w", filename, err)
+	}
+
+	// Keep the partial syntax tree of files with syntax errors, which are common while editing
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filename, data, parser.AllErrors|parser.ParseComments)
+	var syntaxErrors scanner.ErrorList
+	if err != nil && (!errors.As(err, &syntaxErrors) || node == nil) {
 		return nil, fmt.Errorf("failed to parse Go file %s: %w", filename, err)
 	}
 
+	result := analyze(fset, node, data, opts, syntaxErrors)
+	if opts.Strict && len(syntaxErrors) > 0 {
+		return nil, newParseError(filename, result.Diagnostics)
+	}
+	return result, nil
+}
+
+// AnalyzeAST extracts test symbols and diagnostics from a parsed Go file and its source.
+// It lets tools that already have the syntax tree, such as go/analysis passes, share the extraction.
+// If src is nil, characters are counted in bytes regardless of opts.PositionEncoding and
+// comments are not attached to the test cases.
+func AnalyzeAST(fset *token.FileSet, file *ast.File, src []byte, opts Options) *Result {
+	return analyze(fset, file, src, opts, nil)
+}
+
+// analyze extracts test symbols and diagnostics from a file parsed with the given syntax errors
+func analyze(fset *token.FileSet, file *ast.File, src []byte, opts Options, syntaxErrors scanner.ErrorList) *Result {
+	e := &extractor{fset: fset, file: fset.File(file.FileStart), src: src, comments: file.Comments, opts: opts}
+	root := file
+	if len(syntaxErrors) > 0 {
+		syntaxErrors.RemoveMultiples() // keep the first error of each line, as go/parser callers usually do
+		for _, err := range syntaxErrors {
+			e.reportSyntaxError(file, err)
+		}
+		recovered := *file
+		recovered.Decls = recoverFunctions(fset, file, src)
+		root = &recovered
+	}
+
+	e.funcs = map[string]*ast.FuncDecl{}
+	for _, decl := range root.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil {
+			e.funcs[funcDecl.Name.Name] = funcDecl
+		}
+	}
+
 	symbols := []Symbol{}
-	ast.Inspect(node, func(n ast.Node) bool {
-		symbol := extractTestFunction(n, fset)
+	ast.Inspect(root, func(n ast.Node) bool {
+		symbol := e.extractTestFunction(n)
 		if symbol != nil {
 			symbols = append(symbols, *symbol)
 			return false // Don't traverse into this function
@@ -63,11 +275,18 @@ func Parse(filename string, src io.Reader) ([]Symbol, error) {
 		return true
 	})
 
-	return symbols, nil
+	for i, symbol := range symbols {
+		e.checkTestCaseNames(symbol)
+		symbols[i].Children = e.groupCases(symbol.Children)
+	}
+	diagnostics := append([]Diagnostic{}, e.diagnostics...)
+	sortDiagnostics(diagnostics)
+
+	return &Result{Version: SchemaVersion, Symbols: symbols, Diagnostics: diagnostics}
 }
 
-// ParseFile analyzes a Go file and extracts test functions with their test cases.
-func ParseFile(filePath string) ([]Symbol, error) {
+// AnalyzeFile is like ParseFile but also reports diagnostics on the detected test cases.
+func AnalyzeFile(filePath string, opts Options) (*Result, error) {
 	if filePath == "" {
 		return nil, fmt.Errorf("file path cannot be empty")
 	}
@@ -83,20 +302,62 @@ func ParseFile(filePath string) ([]Symbol, error) {
 		_ = f.Close() // ignore error
 	}()
 
-	return Parse(filePath, f)
+	if opts.PackageDir == "" {
+		opts.PackageDir = filepath.Dir(filePath)
+	}
+	return Analyze(filePath, f, opts)
+}
+
+// extractor holds the state of a single analysis
+type extractor struct {
+	fset         *token.FileSet
+	file         *token.File              // file being analyzed
+	src          []byte                   // source of the file, for position encoding
+	syntaxErrors []int                    // byte offsets of the syntax errors of the file
+	funcs        map[string]*ast.FuncDecl // functions of the file by name, for constructors of test cases
+	packageFuncs map[string]*ast.FuncDecl // functions of the other files of the package, loaded on demand
+	nameField    string                   // name field of the table being extracted, in addition to the configured ones
+	comments     []*ast.CommentGroup      // comments of the file, attached to the test cases they document
+	opts         Options
+	diagnostics  []Diagnostic
+}
+
+// table is a composite literal scanned for test cases
+type table struct {
+	lit   *ast.CompositeLit
+	ident *ast.Ident // variable the table is assigned to, nil for inline tables
+	cases []Symbol
+
+	nameField string // name field found in the helper the table is passed to, if any
+	named     bool   // whether the table has named test cases
+	outlined  bool   // whether the test cases of the table are outlined (see Options.AllTables)
+}
+
+// extractTable extracts the test cases of a table of a test function, including those appended to it
+// and those without a name
+func (e *extractor) extractTable(t *table, decl *ast.FuncDecl) {
+	e.nameField = t.nameField
+	defer func() { e.nameField = "" }()
+	t.cases = e.extractFromCompositeLiteral(t.lit)
+	// Pattern: tests = append(tests, tc{...})
+	t.cases = append(t.cases, e.extractAppendedCases(decl.Body, t)...)
+	t.named = len(t.cases) > 0
+	t.cases = append(t.cases, e.extractUnnamedCases(decl, t)...)
+	slices.SortStableFunc(t.cases, compareCases)
 }
 
 // extractTestFunction extracts a test function symbol if the node is a test function
-func extractTestFunction(n ast.Node, fset *token.FileSet) *Symbol {
+func (e *extractor) extractTestFunction(n ast.Node) *Symbol {
 	// Check if node is a function declaration
-	// Pattern: func TestXxx(t *testing.T) {...}
+	// Pattern: func TestXxx(t *testing.T) {...}, func BenchmarkXxx(b *testing.B) {...}
 	funcDecl, ok := n.(*ast.FuncDecl)
 	if !ok {
 		return nil
 	}
 
-	// Skip non-test functions (requires name starts with "Test" and no return values)
-	if !strings.HasPrefix(funcDecl.Name.String(), "Test") || funcDecl.Type.Results != nil {
+	// Skip non-test functions (requires name starts with "Test" or "Benchmark" and no return values)
+	benchmark := strings.HasPrefix(funcDecl.Name.String(), "Benchmark")
+	if !strings.HasPrefix(funcDecl.Name.String(), "Test") && !benchmark || funcDecl.Type.Results != nil {
 		return nil
 	}
 
@@ -106,82 +367,39 @@ func extractTestFunction(n ast.Node, fset *token.FileSet) *Symbol {
 	}
 
 	// Extract test cases from the function body
-	testCases := extractTestCases(funcDecl.Body, fset)
+	tables, testCases := e.recognize(funcDecl, benchmark)
+	e.lintTables(funcDecl, tables)
 	if len(testCases) == 0 {
 		return nil
 	}
 
-	startPos := fset.Position(funcDecl.Pos())
-	endPos := fset.Position(funcDecl.End())
+	detail := "test function"
+	if benchmark {
+		detail = "benchmark function"
+	}
 	return &Symbol{
-		Name:     funcDecl.Name.Name,
-		Detail:   "test function",
-		Kind:     SymbolKindFunction,
-		Range:    toRange(startPos, endPos),
-		Children: testCases,
-	}
-}
-
-// extractTestCases finds and extracts test cases from a function body
-func extractTestCases(body *ast.BlockStmt, fset *token.FileSet) []Symbol {
-	var allTestCases []Symbol
-
-	// Look for test table definitions
-	// Pattern examples:
-	//   tests := []struct{...}{...}              // slice literal
-	//   tests := []Test{...}                     // slice of named type
-	//   tests := Tests{...}                      // type alias (e.g., type Tests []Test)
-	//   tests := map[string]struct{...}{...}     // map with string keys
-	//   for _, tc := range []struct{...}{...}    // inline usage
-	ast.Inspect(body, func(n ast.Node) bool {
-		// Look for variable assignments and range statements
-		switch node := n.(type) {
-		case *ast.AssignStmt:
-			// Pattern: tests := []struct{...}{...}
-			if len(node.Rhs) == 1 {
-				if compLit, ok := node.Rhs[0].(*ast.CompositeLit); ok {
-					testCases := extractFromCompositeLiteral(compLit, fset)
-					allTestCases = append(allTestCases, testCases...)
-				}
-			}
-		case *ast.RangeStmt:
-			// Pattern: for _, tc := range []struct{...}{...}
-			if compLit, ok := node.X.(*ast.CompositeLit); ok {
-				testCases := extractFromCompositeLiteral(compLit, fset)
-				allTestCases = append(allTestCases, testCases...)
-			}
-		case *ast.DeclStmt:
-			// Pattern: var tests = []struct{...}{...}
-			if genDecl, ok := node.Decl.(*ast.GenDecl); ok && genDecl.Tok == token.VAR {
-				for _, spec := range genDecl.Specs {
-					if valueSpec, ok := spec.(*ast.ValueSpec); ok && len(valueSpec.Values) == 1 {
-						if compLit, ok := valueSpec.Values[0].(*ast.CompositeLit); ok {
-							testCases := extractFromCompositeLiteral(compLit, fset)
-							allTestCases = append(allTestCases, testCases...)
-						}
-					}
-				}
-			}
-		}
-		return true
-	})
-
-	return allTestCases
+		Name:           funcDecl.Name.Name,
+		Detail:         detail,
+		Kind:           SymbolKindFunction,
+		Range:          e.nodeRange(funcDecl),
+		SelectionRange: e.nodeRange(funcDecl.Name),
+		Children:       e.groupTables(tables, testCases),
+	}
 }
 
 // extractFromCompositeLiteral extracts test cases from a composite literal
-func extractFromCompositeLiteral(compLit *ast.CompositeLit, fset *token.FileSet) []Symbol {
+func (e *extractor) extractFromCompositeLiteral(compLit *ast.CompositeLit) []Symbol {
 	// Check if it's a map type
 	if _, ok := compLit.Type.(*ast.MapType); ok {
-		return extractTestCasesFromMap(compLit, fset)
+		return e.extractTestCasesFromMap(compLit)
 	}
 
 	// Otherwise, treat as slice/array
-	return extractTestCasesFromSlice(compLit, fset)
+	return e.extractTestCasesFromSlice(compLit)
 }
 
 // extractTestCasesFromMap extracts test cases from map pattern
-func extractTestCasesFromMap(compLit *ast.CompositeLit, fset *token.FileSet) []Symbol {
+func (e *extractor) extractTestCasesFromMap(compLit *ast.CompositeLit) []Symbol {
 	var testCases []Symbol
 
 	for _, elt := range compLit.Elts {
@@ -194,15 +412,21 @@ func extractTestCasesFromMap(compLit *ast.CompositeLit, fset *token.FileSet) []S
 		if !ok {
 			continue
 		}
+		if testName == "" {
+			continue
+		}
 
-		testCases = append(testCases, createTestCaseSymbol(testName, kv, fset))
+		fields := caseFields(kv.Value, extractStructFields(compLit.Type), nil, e.fset)
+		testCase := e.createTestCaseSymbol(testName, kv, kv.Key, fields)
+		testCase.Reference = e.funcReference(kv.Value)
+		testCases = append(testCases, testCase)
 	}
 
 	return testCases
 }
 
 // extractTestCasesFromSlice extracts test cases from slice/array pattern
-func extractTestCasesFromSlice(compLit *ast.CompositeLit, fset *token.FileSet) []Symbol {
+func (e *extractor) extractTestCasesFromSlice(compLit *ast.CompositeLit) []Symbol {
 	var testCases []Symbol
 
 	// Extract struct fields if available
@@ -212,38 +436,143 @@ func extractTestCasesFromSlice(compLit *ast.CompositeLit, fset *token.FileSet) [
 	// We check all composite literals since we can't always determine
 	// if a type alias refers to a slice without type information
 	for _, elt := range compLit.Elts {
-		// Each element should be a struct literal
-		// Pattern: {name: "test1", input: "value", want: "expected"}
-		caseLit, ok := elt.(*ast.CompositeLit)
-		if !ok {
+		if testCase, ok := e.extractSliceElement(elt, structFields); ok {
+			testCases = append(testCases, testCase)
+		}
+	}
+
+	return testCases
+}
+
+// extractSliceElement extracts a test case from an element of a slice or array
+func (e *extractor) extractSliceElement(elt ast.Expr, structFields []*ast.Field) (Symbol, bool) {
+	// Each element should be a struct literal
+	// Pattern: {name: "test1", input: "value", want: "expected"}
+	caseLit, ok := elt.(*ast.CompositeLit)
+	if !ok {
+		// Pattern: ok("name", ...), newCase("name").With(...)
+		return e.extractTestCaseFromCall(elt)
+	}
+
+	testName, nameExpr := e.extractTestName(caseLit, structFields)
+	if testName == "" {
+		return Symbol{}, false
+	}
+
+	fields := caseFields(caseLit, structFields, nameExpr, e.fset)
+	testCase := e.createTestCaseSymbol(testName, caseLit, nameExpr, fields)
+	testCase.Reference = e.funcReference(caseLit)
+	return testCase, true
+}
+
+// funcReference returns the range of the declaration of the function run by a test case value:
+// the value itself, as in map[string]func(*testing.T){"empty": testEmpty}, or the first field
+// naming a function, as in []struct{name string; fn func(*testing.T)}{{"empty", testEmpty}}.
+// It returns nil if there is no such function in the file.
+func (e *extractor) funcReference(value ast.Expr) *Range {
+	if caseLit, ok := value.(*ast.CompositeLit); ok {
+		for _, elt := range caseLit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			if _, ok := elt.(*ast.CompositeLit); ok {
+				continue // not a function
+			}
+			if r := e.funcReference(elt); r != nil {
+				return r
+			}
+		}
+		return nil
+	}
+
+	ident, ok := value.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	decl := e.funcs[ident.Name]
+	if decl == nil {
+		return nil
+	}
+	r := e.nodeRange(decl)
+	return &r
+}
+
+// createTestCaseSymbol creates a Symbol for a test case spanning node, named by nameNode
+func (e *extractor) createTestCaseSymbol(testName string, node, nameNode ast.Node, fields []Field) Symbol {
+	return Symbol{
+		Name:           testName,
+		Detail:         "test case",
+		Kind:           SymbolKindStruct,
+		Range:          e.nodeRange(node),
+		SelectionRange: e.nodeRange(nameNode),
+		Fields:         fields,
+	}
+}
+
+// caseFields extracts the field values of a test case value, skipping the test name expression
+func caseFields(value ast.Expr, structFields []*ast.Field, nameExpr ast.Expr, fset *token.FileSet) []Field {
+	caseLit, ok := value.(*ast.CompositeLit)
+	if !ok {
+		// Pattern: "one": 1
+		return []Field{{Value: formatExpr(value, fset)}}
+	}
+
+	names := fieldNames(structFields)
+	var fields []Field
+	for i, elt := range caseLit.Elts {
+		if elt == nameExpr {
 			continue
 		}
 
-		testName := extractTestName(caseLit, structFields)
-		if testName == "" {
+		// Key-value form: {input: 1}
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if kv.Value == nameExpr {
+				continue
+			}
+			fields = append(fields, Field{Name: formatExpr(kv.Key, fset), Value: formatExpr(kv.Value, fset)})
 			continue
 		}
 
-		testCases = append(testCases, createTestCaseSymbol(testName, caseLit, fset))
+		// Positional form: {1, 2}
+		name := strconv.Itoa(i)
+		if i < len(names) {
+			name = names[i]
+		}
+		fields = append(fields, Field{Name: name, Value: formatExpr(elt, fset)})
 	}
+	return fields
+}
 
-	return testCases
+// fieldNames flattens struct field definitions into field names, in declaration order
+func fieldNames(structFields []*ast.Field) []string {
+	var names []string
+	for _, field := range structFields {
+		if len(field.Names) == 0 {
+			// Embedded field
+			names = append(names, formatExpr(field.Type, nil))
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
 }
 
-// createTestCaseSymbol creates a Symbol for a test case
-func createTestCaseSymbol(testName string, node ast.Node, fset *token.FileSet) Symbol {
-	startPos := fset.Position(node.Pos())
-	endPos := fset.Position(node.End())
-	return Symbol{
-		Name:   testName,
-		Detail: "test case",
-		Kind:   SymbolKindStruct,
-		Range:  toRange(startPos, endPos),
+// formatExpr returns the source text of an expression in normalized form
+func formatExpr(expr ast.Expr, fset *token.FileSet) string {
+	if fset == nil {
+		fset = token.NewFileSet()
 	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return ""
+	}
+	return Normalize(buf.String())
 }
 
-// extractTestName extracts the test name from a struct literal
-func extractTestName(caseLit *ast.CompositeLit, structFields []*ast.Field) string {
+// extractTestName extracts the test name and the expression holding it from a struct literal
+func (e *extractor) extractTestName(caseLit *ast.CompositeLit, structFields []*ast.Field) (string, ast.Expr) {
 	// First try key-value form:
 	//   {name: "test1", ...}
 	for _, kv := range caseLit.Elts {
@@ -260,7 +589,7 @@ func extractTestName(caseLit *ast.CompositeLit, structFields []*ast.Field) strin
 		}
 
 		// Check if the field name is one of the common test name fields
-		if !isTestNameField(ident.Name) {
+		if !e.isTestNameField(ident.Name) {
 			continue
 		}
 
@@ -270,12 +599,12 @@ func extractTestName(caseLit *ast.CompositeLit, structFields []*ast.Field) strin
 		if !ok {
 			continue
 		}
-		return testName
+		return testName, kve.Value
 	}
 
 	// If no key-value form found, try positional form:
 	//   {"test1", ...}
-	return extractTestNameFromPositional(caseLit, structFields)
+	return e.extractTestNameFromPositional(caseLit, structFields)
 }
 
 // extractStructFields extracts field definitions from a struct type
@@ -289,6 +618,9 @@ func extractStructFields(typeExpr ast.Expr) []*ast.Field {
 	case *ast.ArrayType:
 		// []struct{...}
 		return extractStructFields(t.Elt)
+	case *ast.MapType:
+		// map[string]struct{...}
+		return extractStructFields(t.Value)
 	case *ast.StructType:
 		// struct{...}
 		return t.Fields.List
@@ -299,15 +631,10 @@ func extractStructFields(typeExpr ast.Expr) []*ast.Field {
 }
 
 // extractTestNameFromPositional extracts test name from positional struct literal
-func extractTestNameFromPositional(caseLit *ast.CompositeLit, structFields []*ast.Field) string {
+func (e *extractor) extractTestNameFromPositional(caseLit *ast.CompositeLit, structFields []*ast.Field) (string, ast.Expr) {
 	// Find the position of any test name field
-	for i, field := range structFields {
-		if len(field.Names) == 0 {
-			continue
-		}
-
-		fieldName := field.Names[0].Name
-		if !isTestNameField(fieldName) {
+	for i, fieldName := range fieldNames(structFields) {
+		if !e.isTestNameField(fieldName) {
 			continue
 		}
 
@@ -322,14 +649,14 @@ func extractTestNameFromPositional(caseLit *ast.CompositeLit, structFields []*as
 			continue
 		}
 
-		return testName
+		return testName, caseLit.Elts[i]
 	}
 
-	return ""
+	return "", nil
 }
 
-// testNameFields contains field names commonly used for test case names
-var testNameFields = []string{
+// DefaultNameFields contains field names commonly used for test case names
+var DefaultNameFields = []string{
 	"name",
 	"testName",
 	"desc",
@@ -338,9 +665,16 @@ var testNameFields = []string{
 	"scenario",
 }
 
-// isTestNameField checks if a field name is commonly used for test case names
-func isTestNameField(fieldName string) bool {
-	return slices.ContainsFunc(testNameFields, func(name string) bool {
+// isTestNameField checks if a field name is one of the configured test case name fields
+func (e *extractor) isTestNameField(fieldName string) bool {
+	if e.nameField != "" && fieldName == e.nameField {
+		return true
+	}
+	nameFields := e.opts.NameFields
+	if nameFields == nil {
+		nameFields = DefaultNameFields
+	}
+	return slices.ContainsFunc(nameFields, func(name string) bool {
 		return strings.EqualFold(fieldName, name)
 	})
 }
@@ -355,14 +689,36 @@ func extractStringLiteral(expr ast.Expr) (string, bool) {
 	if err != nil {
 		return "", false
 	}
+	if strings.HasPrefix(basicLit.Value, "`") {
+		// Carriage returns are discarded from raw string literals, as in files with CRLF line endings
+		unquoted = strings.ReplaceAll(unquoted, "\r", "")
+	}
 
 	return unquoted, true
 }
 
-// toRange converts token positions to VS Code range format (0-indexed)
-func toRange(start, end token.Position) Range {
-	return Range{
-		Start: Line{Line: start.Line - 1, Character: start.Column - 1},
-		End:   Line{Line: end.Line - 1, Character: end.Column - 1},
+// Normalize returns the tokens of Go source text separated by single spaces,
+// so that formatting differences such as indentation or trailing commas disappear.
+func Normalize(src string) string {
+	var toks []string
+	var sc scanner.Scanner
+	fset := token.NewFileSet()
+	sc.Init(fset.AddFile("", -1, len(src)), []byte(src), nil, 0)
+	for {
+		_, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // automatically inserted
+		}
+		if (tok == token.RBRACE || tok == token.RPAREN || tok == token.RBRACK) && len(toks) > 0 && toks[len(toks)-1] == "," {
+			toks = toks[:len(toks)-1] // trailing comma
+		}
+		if lit == "" {
+			lit = tok.String()
+		}
+		toks = append(toks, lit)
 	}
+	return strings.Join(toks, " ")
 }
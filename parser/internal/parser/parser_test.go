@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"errors"
+	"io/fs"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -13,6 +16,7 @@ func TestParse(t *testing.T) {
 	tests := []struct {
 		name     string
 		filePath string
+		opts     Options
 		want     []Symbol
 		wantErr  bool
 	}{
@@ -84,6 +88,7 @@ func TestParse(t *testing.T) {
 		{
 			name:     "support for various field names",
 			filePath: "testdata/various_fields_test.go",
+			opts:     Options{AllTables: true}, // the tables are not run
 			want: []Symbol{
 				{
 					Name:   "TestVariousFields",
@@ -118,6 +123,7 @@ func TestParse(t *testing.T) {
 		{
 			name:     "multiple test tables",
 			filePath: "testdata/multiple_tables_test.go",
+			opts:     Options{AllTables: true}, // the tables are not run
 			want: []Symbol{
 				{
 					Name:   "TestMultipleTables",
@@ -164,6 +170,7 @@ func TestParse(t *testing.T) {
 		{
 			name:     "case insensitive field matching",
 			filePath: "testdata/case_insensitive_test.go",
+			opts:     Options{AllTables: true}, // the tables are not run
 			want: []Symbol{
 				{
 					Name:   "TestCaseInsensitive",
@@ -190,6 +197,30 @@ func TestParse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:     "file with syntax errors",
+			filePath: "testdata/syntax_error_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestMidEdit",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "complete", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "being typed", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestAfterBrokenFunction",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "still found", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:     "empty file path",
 			filePath: "",
@@ -420,6 +451,254 @@ func TestParse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:     "constructor calls",
+			filePath: "testdata/constructor_calls_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestParseInt",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "parses int", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "rejects empty", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "literal case", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestBuilder",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "built", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "built again", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+			},
+		},
+		{
+			name:     "tables passed to test helpers",
+			filePath: "testdata/runner_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestRunnerVariable",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "labeled by helper", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "in another file", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestRunnerInline",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "inline table", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "same file helper", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestExternalRunner",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "default name field", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+			},
+		},
+		{
+			name:     "cases appended to a table",
+			filePath: "testdata/append_cases_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestAppendedCases",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "relative", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "absolute", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "drive letter", Detail: `conditional test case (if runtime.GOOS == "windows")`, Kind: SymbolKindStruct},
+						{Name: "UNC path", Detail: `conditional test case (if runtime.GOOS == "windows")`, Kind: SymbolKindStruct},
+						{Name: "backslash", Detail: "conditional test case", Kind: SymbolKindStruct},
+						{Name: "spread", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+			},
+		},
+		{
+			name:     "names generated over literal ranges",
+			filePath: "testdata/generated_names_test.go",
+			want: []Symbol{
+				{
+					Name:   "BenchmarkSizes",
+					Detail: "benchmark function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "size=10", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "size=100", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "size=1000", Detail: "generated test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "BenchmarkCrossProduct",
+					Detail: "benchmark function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "fast/1", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "fast/2", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "slow/1", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "slow/2", Detail: "generated test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "BenchmarkTable",
+					Detail: "benchmark function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "small", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "large", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestIndexes",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "shard 0", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "shard 1", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "shard 2", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "#0 ratio=0.5", Detail: "generated test case", Kind: SymbolKindStruct},
+						{Name: "#1 ratio=1.0", Detail: "generated test case", Kind: SymbolKindStruct},
+					},
+				},
+			},
+		},
+		{
+			name:     "unnamed cases",
+			filePath: "testdata/unnamed_cases_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestIndexNames",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "0", Detail: "unnamed test case", Kind: SymbolKindStruct},
+						{Name: "1", Detail: "unnamed test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestSprintIndexNames",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "case-0", Detail: "unnamed test case", Kind: SymbolKindStruct},
+						{Name: "case-1", Detail: "unnamed test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestPlaceholderNames",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "first", Detail: "test case", Kind: SymbolKindStruct},
+						{Name: "#1", Detail: "unnamed test case", Kind: SymbolKindStruct, Placeholder: true},
+					},
+				},
+			},
+		},
+		{
+			name:     "case comments",
+			filePath: "testdata/case_comments_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestCommentNames",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{
+							Name:           "rejects negative input",
+							Detail:         "unnamed test case",
+							Kind:           SymbolKindStruct,
+							LeadingComment: "rejects negative input",
+							Placeholder:    true,
+						},
+						{
+							Name:            "accepts zero",
+							Detail:          "unnamed test case",
+							Kind:            SymbolKindStruct,
+							TrailingComment: "accepts zero",
+							Placeholder:     true,
+						},
+						{Name: "#2", Detail: "unnamed test case", Kind: SymbolKindStruct, Placeholder: true},
+					},
+				},
+				{
+					Name:   "TestCommentDetails",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{
+							Name:           "multi-line",
+							Detail:         "test case // Leading comments may span several lines.",
+							Kind:           SymbolKindStruct,
+							LeadingComment: "Leading comments may span\nseveral lines.",
+						},
+						{
+							Name:            "trailing",
+							Detail:          "test case // comments are added to the detail",
+							Kind:            SymbolKindStruct,
+							TrailingComment: "comments are added to the detail",
+						},
+						{Name: "plain", Detail: "test case", Kind: SymbolKindStruct},
+						{
+							Name:           "documented but unnamed",
+							Detail:         "unnamed test case",
+							Kind:           SymbolKindStruct,
+							LeadingComment: "documented but unnamed",
+							Placeholder:    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "literals that are not test tables",
+			filePath: "testdata/fake_tables_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestKnownRunnerInLoop",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "run by a helper", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+			},
+		},
+		{
+			name:     "tables that are not run",
+			filePath: "testdata/unrun_tables_test.go",
+			want: []Symbol{
+				{
+					Name:   "TestRunTable",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "run as a subtest", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+				{
+					Name:   "TestIndexLoop",
+					Detail: "test function",
+					Kind:   SymbolKindFunction,
+					Children: []Symbol{
+						{Name: "run by index", Detail: "test case", Kind: SymbolKindStruct},
+					},
+				},
+			},
+		},
 		{
 			name:     "positional field form",
 			filePath: "testdata/positional_field_form.go",
@@ -450,20 +729,1020 @@ func TestParse(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := ParseFile(tt.filePath)
+			result, err := AnalyzeFile(tt.filePath, tt.opts)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("ParseFile() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("AnalyzeFile() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err != nil {
-				t.Logf("ParseFile() error = %v", err)
+				t.Logf("AnalyzeFile() error = %v", err)
 			}
 
 			if !tt.wantErr {
-				if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Symbol{}, "Range")); diff != "" {
-					t.Errorf("ParseFile() mismatch (-want +got):\n%s", diff)
+				if diff := cmp.Diff(tt.want, result.Symbols, cmpopts.IgnoreFields(Symbol{}, "Range", "SelectionRange", "Fields")); diff != "" {
+					t.Errorf("AnalyzeFile() mismatch (-want +got):\n%s", diff)
 				}
 			}
 		})
 	}
 }
+
+func TestAnalyzeDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		filePath string
+		opts     Options
+		want     []Diagnostic
+	}{
+		{
+			name:     "no diagnostics",
+			filePath: "testdata/basic_table_test.go",
+			want:     []Diagnostic{},
+		},
+		{
+			name:     "literals that are not test tables",
+			filePath: "testdata/fake_tables_test.go",
+			want:     []Diagnostic{},
+		},
+		{
+			name:     "tables that are not run",
+			filePath: "testdata/unrun_tables_test.go",
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 30, Character: 1}, End: Line{Line: 30, Character: 6}},
+					Severity: SeverityWarning,
+					Rule:     RuleUnusedTable,
+					Message:  `test table "tests" is never ranged over with t.Run or passed to a test helper`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 44, Character: 1}, End: Line{Line: 44, Character: 6}},
+					Severity: SeverityWarning,
+					Rule:     RuleUnusedTable,
+					Message:  `test table "cases" is never ranged over with t.Run or passed to a test helper`,
+				},
+			},
+		},
+		{
+			name:     "unnamed case named by its comment",
+			filePath: "testdata/case_comments_test.go",
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 35, Character: 2}, End: Line{Line: 35, Character: 14}},
+					Severity: SeverityWarning,
+					Rule:     RuleMissingName,
+					Message:  `test case has no name and is shown as "documented but unnamed" in the outline`,
+				},
+			},
+		},
+		{
+			name:     "duplicate, colliding and empty names",
+			filePath: "testdata/duplicate_names_test.go",
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 10, Character: 2}, End: Line{Line: 10, Character: 25}},
+					Severity: SeverityWarning,
+					Rule:     RuleNameCollision,
+					Message:  `test case name "a_b" collides with "a b" after go test normalization; go test runs it as "a_b#01"`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 11, Character: 2}, End: Line{Line: 11, Character: 25}},
+					Severity: SeverityWarning,
+					Rule:     RuleDuplicateName,
+					Message:  `duplicate test case name "a b"; go test runs it as "a_b#02"`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 12, Character: 9}, End: Line{Line: 12, Character: 11}},
+					Severity: SeverityWarning,
+					Rule:     RuleEmptyName,
+					Message:  "test case has an empty name; go test names it by its index (#00, #01, ...)",
+				},
+				{
+					Range:    Range{Start: Line{Line: 24, Character: 2}, End: Line{Line: 24, Character: 4}},
+					Severity: SeverityWarning,
+					Rule:     RuleEmptyName,
+					Message:  "test case has an empty name; go test names it by its index (#00, #01, ...)",
+				},
+			},
+		},
+		{
+			name:     "tables passed to test helpers",
+			filePath: "testdata/runner_test.go",
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 41, Character: 1}, End: Line{Line: 41, Character: 7}},
+					Severity: SeverityWarning,
+					Rule:     RuleUnusedTable,
+					Message:  `test table "unused" is never ranged over with t.Run or passed to a test helper`,
+				},
+			},
+		},
+		{
+			name:     "syntax errors",
+			filePath: "testdata/syntax_error_test.go",
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 10, Character: 28}, End: Line{Line: 10, Character: 28}},
+					Severity: SeverityError,
+					Rule:     RuleSyntaxError,
+					Message:  "expected operand, found '}'",
+				},
+				{
+					Range:    Range{Start: Line{Line: 14, Character: 1}, End: Line{Line: 14, Character: 1}},
+					Severity: SeverityError,
+					Rule:     RuleSyntaxError,
+					Message:  "missing ',' in composite literal",
+				},
+				{
+					Range:    Range{Start: Line{Line: 22, Character: 1}, End: Line{Line: 22, Character: 1}},
+					Severity: SeverityError,
+					Rule:     RuleSyntaxError,
+					Message:  "missing ',' in composite literal",
+				},
+				{
+					Range:    Range{Start: Line{Line: 25, Character: 2}, End: Line{Line: 25, Character: 2}},
+					Severity: SeverityError,
+					Rule:     RuleSyntaxError,
+					Message:  "expected ';', found 'EOF'",
+				},
+			},
+		},
+		{
+			name:     "table lint rules",
+			filePath: "testdata/lint_rules_test.go",
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 11, Character: 2}, End: Line{Line: 11, Character: 31}},
+					Severity: SeverityInformation,
+					Rule:     RuleInconsistentPrefix,
+					Message:  `test case name "no prefix" has no prefix like the other cases in the table (e.g. "valid: one")`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 12, Character: 2}, End: Line{Line: 12, Character: 28}},
+					Severity: SeverityInformation,
+					Rule:     RuleMixedElements,
+					Message:  "positional test case in a table of keyed test cases",
+					Fixes: []Fix{{
+						Message: "Convert to keyed fields",
+						Edits: []TextEdit{
+							{Range: Range{Start: Line{Line: 12, Character: 3}, End: Line{Line: 12, Character: 3}}, NewText: "name: "},
+							{Range: Range{Start: Line{Line: 12, Character: 26}, End: Line{Line: 12, Character: 26}}, NewText: "input: "},
+						},
+					}},
+				},
+				{
+					Range:    Range{Start: Line{Line: 13, Character: 2}, End: Line{Line: 13, Character: 30}},
+					Severity: SeverityInformation,
+					Rule:     RuleInconsistentPrefix,
+					Message:  `test case name " padded " has no prefix like the other cases in the table (e.g. "valid: one")`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 13, Character: 9}, End: Line{Line: 13, Character: 19}},
+					Severity: SeverityWarning,
+					Rule:     RuleNameWhitespace,
+					Message:  `test case name " padded " has leading or trailing whitespace`,
+					Fixes: []Fix{{
+						Message: "Trim whitespace",
+						Edits: []TextEdit{
+							{Range: Range{Start: Line{Line: 13, Character: 9}, End: Line{Line: 13, Character: 19}}, NewText: `"padded"`},
+						},
+					}},
+				},
+				{
+					Range:    Range{Start: Line{Line: 14, Character: 2}, End: Line{Line: 14, Character: 12}},
+					Severity: SeverityWarning,
+					Rule:     RuleMissingName,
+					Message:  `test case has no name and is shown as "#5" in the outline`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 25, Character: 1}, End: Line{Line: 25, Character: 6}},
+					Severity: SeverityWarning,
+					Rule:     RuleUnusedTable,
+					Message:  `test table "cases" is never ranged over with t.Run or passed to a test helper`,
+				},
+			},
+		},
+		{
+			name:     "duplicate cases and inputs",
+			filePath: "testdata/duplicate_cases_test.go",
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 13, Character: 2}, End: Line{Line: 13, Character: 41}},
+					Severity: SeverityWarning,
+					Rule:     RuleDuplicateCase,
+					Message:  `test case "also one" has the same field values as "one"`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 14, Character: 2}, End: Line{Line: 14, Character: 57}},
+					Severity: SeverityInformation,
+					Rule:     RuleDuplicateInputs,
+					Message:  `test case "one again" has the same inputs as "one" and differs only in want, wantErr`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 16, Character: 2}, End: Line{Line: 17, Character: 13}},
+					Severity: SeverityWarning,
+					Rule:     RuleDuplicateCase,
+					Message:  `test case "reformatted" has the same field values as "formatted"`,
+				},
+			},
+		},
+		{
+			name:     "duplicate inputs with configured input and expectation fields",
+			filePath: "testdata/duplicate_cases_test.go",
+			opts: Options{
+				Rules:             map[string]string{RuleDuplicateCase: SeverityOff},
+				InputFields:       []string{"INPUT"},
+				ExpectationFields: []string{"want"},
+			},
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 14, Character: 2}, End: Line{Line: 14, Character: 57}},
+					Severity: SeverityInformation,
+					Rule:     RuleDuplicateInputs,
+					Message:  `test case "one again" has the same inputs as "one" and differs only in want, wantErr`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 18, Character: 2}, End: Line{Line: 18, Character: 56}},
+					Severity: SeverityInformation,
+					Rule:     RuleDuplicateInputs,
+					Message:  `test case "other setup" has the same inputs as "formatted" and differs only in setup, want`,
+				},
+			},
+		},
+		{
+			name:     "rules configured off and with other severities",
+			filePath: "testdata/lint_rules_test.go",
+			opts: Options{Rules: map[string]string{
+				RuleInconsistentPrefix: SeverityOff,
+				RuleMixedElements:      SeverityOff,
+				RuleNameWhitespace:     SeverityOff,
+				RuleMissingName:        "error",
+				RuleUnusedTable:        "hint",
+			}},
+			want: []Diagnostic{
+				{
+					Range:    Range{Start: Line{Line: 14, Character: 2}, End: Line{Line: 14, Character: 12}},
+					Severity: SeverityError,
+					Rule:     RuleMissingName,
+					Message:  `test case has no name and is shown as "#5" in the outline`,
+				},
+				{
+					Range:    Range{Start: Line{Line: 25, Character: 1}, End: Line{Line: 25, Character: 6}},
+					Severity: SeverityHint,
+					Rule:     RuleUnusedTable,
+					Message:  `test table "cases" is never ranged over with t.Run or passed to a test helper`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := AnalyzeFile(tt.filePath, tt.opts)
+			if err != nil {
+				t.Fatalf("AnalyzeFile() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got.Diagnostics, cmpopts.IgnoreFields(Line{}, "Offset")); diff != "" {
+				t.Errorf("AnalyzeFile() diagnostics mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "zero value", opts: Options{}},
+		{name: "field patterns", opts: Options{InputFields: []string{"in*"}, ExpectationFields: []string{"want*", "[ex]pect"}}},
+		{name: "unknown rule", opts: Options{Rules: map[string]string{"no-such-rule": "off"}}, wantErr: true},
+		{name: "malformed pattern", opts: Options{ExpectationFields: []string{"want["}}, wantErr: true},
+		{name: "table pattern", opts: Options{Patterns: []Pattern{{Kind: PatternCall, Func: "runCases", Arg: 1}}, Recognizers: []string{"call:runCases"}}},
+		{name: "negative max expansions", opts: Options{MaxExpansions: -1}, wantErr: true},
+		{name: "unknown pattern kind", opts: Options{Patterns: []Pattern{{Kind: "method", Func: "run"}}}, wantErr: true},
+		{name: "pattern without function", opts: Options{Patterns: []Pattern{{Kind: PatternWrapper}}}, wantErr: true},
+		{name: "duplicate pattern name", opts: Options{Patterns: []Pattern{{Name: RecognizerSlice, Kind: PatternWrapper, Func: "check"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		patterns []Pattern
+		want     map[string][]string
+	}{
+		{
+			name: "no patterns",
+			want: map[string][]string{},
+		},
+		{
+			name:     "call",
+			patterns: []Pattern{{Kind: PatternCall, Func: "runCases", Arg: 1}},
+			want: map[string][]string{
+				"TestRunCases":    {"passed table", "another case"},
+				"TestConstructor": {"constructed", "constructed too"}, // confirmed by the definition of newCase
+			},
+		},
+		{
+			name:     "call and constructor",
+			patterns: []Pattern{{Kind: PatternCall, Func: "runCases", Arg: 1}, {Kind: PatternConstructor, Func: "newCase", Arg: 0}},
+			want: map[string][]string{
+				"TestRunCases":    {"passed table", "another case"},
+				"TestConstructor": {"constructed", "constructed too"},
+			},
+		},
+		{
+			name:     "constructor",
+			patterns: []Pattern{{Kind: PatternConstructor, Func: "newCase", Arg: 0}},
+			want:     map[string][]string{"TestConstructor": {"constructed", "constructed too"}},
+		},
+		{
+			name:     "wrapper",
+			patterns: []Pattern{{Kind: PatternWrapper, Func: "check", Arg: 1}},
+			want:     map[string][]string{"TestWrapper": {"first check", "second check"}},
+		},
+		{
+			name:     "argument position without a name",
+			patterns: []Pattern{{Kind: PatternWrapper, Func: "check", Arg: 2}},
+			want:     map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Only the patterns are enabled, as the built-in runner recognizer finds the table passed to runCases too
+			opts := Options{Patterns: tt.patterns, Recognizers: []string{}}
+			for _, p := range tt.patterns {
+				opts.Recognizers = append(opts.Recognizers, p.recognizerName())
+			}
+			result, err := AnalyzeFile("testdata/patterns/custom_patterns_test.go", opts)
+			if err != nil {
+				t.Fatalf("AnalyzeFile() error = %v", err)
+			}
+			got := map[string][]string{}
+			for _, fn := range result.Symbols {
+				for _, c := range fn.Children {
+					got[fn.Name] = append(got[fn.Name], c.Name)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("AnalyzeFile() case names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rules   map[string]string
+		wantErr bool
+	}{
+		{name: "nil", rules: nil},
+		{name: "known rules", rules: map[string]string{RuleUnusedTable: "off", RuleEmptyName: "error"}},
+		{name: "unknown rule", rules: map[string]string{"no-such-rule": "warning"}, wantErr: true},
+		{name: "unknown severity", rules: map[string]string{RuleUnusedTable: "fatal"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := ValidateRules(tt.rules); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectionRanges(t *testing.T) {
+	t.Parallel()
+
+	type selection struct {
+		Name           string
+		SelectionRange Range
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		want     []selection
+	}{
+		{
+			name:     "positional name literal",
+			filePath: "testdata/positional_field_form.go",
+			want: []selection{
+				{Name: "TestPositionalFieldForm", SelectionRange: Range{Start: Line{Line: 4, Character: 5}, End: Line{Line: 4, Character: 28}}},
+				{Name: "normal case", SelectionRange: Range{Start: Line{Line: 10, Character: 3}, End: Line{Line: 10, Character: 16}}},
+				{Name: "zero value", SelectionRange: Range{Start: Line{Line: 11, Character: 3}, End: Line{Line: 11, Character: 15}}},
+			},
+		},
+		{
+			name:     "map keys",
+			filePath: "testdata/map_test_cases.go",
+			want: []selection{
+				{Name: "TestWithMap", SelectionRange: Range{Start: Line{Line: 4, Character: 5}, End: Line{Line: 4, Character: 16}}},
+				{Name: "normal case: basic scenario", SelectionRange: Range{Start: Line{Line: 10, Character: 2}, End: Line{Line: 10, Character: 31}}},
+				{Name: "normal case: zero value", SelectionRange: Range{Start: Line{Line: 15, Character: 2}, End: Line{Line: 15, Character: 27}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			symbols, err := ParseFile(tt.filePath)
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+
+			fn := symbols[0]
+			got := []selection{{Name: fn.Name, SelectionRange: fn.SelectionRange}}
+			for _, c := range fn.Children[:len(tt.want)-1] {
+				got = append(got, selection{Name: c.Name, SelectionRange: c.SelectionRange})
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Line{}, "Offset")); diff != "" {
+				t.Errorf("ParseFile() selection ranges mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMaxExpansions(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestProduct(t *testing.T) {
+	for _, a := range []int{1, 2, 3} {
+		for _, b := range []int{1, 2, 3} {
+			t.Run(fmt.Sprintf("%d*%d", a, b), nil)
+		}
+	}
+}
+`
+
+	tests := []struct {
+		name          string
+		maxExpansions int
+		want          []string
+	}{
+		{name: "default", want: []string{"1*1", "1*2", "1*3", "2*1", "2*2", "2*3", "3*1", "3*2", "3*3"}},
+		{name: "capped", maxExpansions: 4, want: []string{"1*1", "1*2", "1*3", "2*1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Analyze("product_test.go", strings.NewReader(src), Options{MaxExpansions: tt.maxExpansions})
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			var got []string
+			for _, c := range result.Symbols[0].Children {
+				got = append(got, c.Name)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Analyze() case names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMaxExpansionsHugeRange(t *testing.T) {
+	t.Parallel()
+
+	// Binding every iteration of the loop would exhaust memory
+	const src = `package p
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHuge(t *testing.T) {
+	for i := range 200000000 {
+		t.Run(fmt.Sprint(i), nil)
+	}
+}
+`
+
+	result, err := Analyze("huge_test.go", strings.NewReader(src), Options{})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if got := len(result.Symbols[0].Children); got != DefaultMaxExpansions {
+		t.Errorf("Analyze() generated %d test cases, want %d", got, DefaultMaxExpansions)
+	}
+}
+
+func TestFuncReferences(t *testing.T) {
+	t.Parallel()
+
+	type reference struct {
+		Name      string
+		Reference *Range
+	}
+
+	testEmpty := &Range{Start: Line{Line: 30, Character: 0}, End: Line{Line: 32, Character: 1}}
+	testBig := &Range{Start: Line{Line: 34, Character: 0}, End: Line{Line: 36, Character: 1}}
+	want := map[string][]reference{
+		"TestFuncMap": {
+			{Name: "empty", Reference: testEmpty},
+			{Name: "big", Reference: testBig},
+			{Name: "inline"},
+			{Name: "other package"},
+		},
+		"TestFuncSlice": {
+			{Name: "empty", Reference: testEmpty},
+			{Name: "big", Reference: testBig},
+		},
+	}
+
+	symbols, err := ParseFile("testdata/func_tables_test.go")
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	got := map[string][]reference{}
+	for _, fn := range symbols {
+		for _, c := range fn.Children {
+			got[fn.Name] = append(got[fn.Name], reference{Name: c.Name, Reference: c.Reference})
+		}
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Line{}, "Offset")); diff != "" {
+		t.Errorf("ParseFile() references mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPositionEncoding(t *testing.T) {
+	t.Parallel()
+
+	// The function name follows a BOM, and the case name follows "é🎉" on the line after a CRLF line break
+	const src = "\uFEFFpackage p; import \"testing\"; func TestX(t *testing.T) {\r\n" +
+		"tests := []struct{ name, v string }{{v: \"é🎉\", name: \"x\"}}\r\n" +
+		"for _, tt := range tests { t.Run(tt.name, nil) } }\r\n"
+
+	tests := []struct {
+		name     string
+		encoding string
+		wantFunc Line
+		wantCase Line
+	}{
+		{
+			name:     "default is utf-16",
+			wantFunc: Line{Line: 0, Character: 34, Offset: 37},
+			wantCase: Line{Line: 1, Character: 53, Offset: 116},
+		},
+		{
+			name:     "utf-8",
+			encoding: EncodingUTF8,
+			wantFunc: Line{Line: 0, Character: 34, Offset: 37},
+			wantCase: Line{Line: 1, Character: 56, Offset: 116},
+		},
+		{
+			name:     "utf-16",
+			encoding: EncodingUTF16,
+			wantFunc: Line{Line: 0, Character: 34, Offset: 37},
+			wantCase: Line{Line: 1, Character: 53, Offset: 116},
+		},
+		{
+			name:     "utf-32",
+			encoding: EncodingUTF32,
+			wantFunc: Line{Line: 0, Character: 34, Offset: 37},
+			wantCase: Line{Line: 1, Character: 52, Offset: 116},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Analyze("x_test.go", strings.NewReader(src), Options{PositionEncoding: tt.encoding})
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if len(got.Symbols) != 1 || len(got.Symbols[0].Children) != 1 {
+				t.Fatalf("Analyze() symbols = %+v, want one test function with one case", got.Symbols)
+			}
+
+			if diff := cmp.Diff(tt.wantFunc, got.Symbols[0].SelectionRange.Start); diff != "" {
+				t.Errorf("function name position mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCase, got.Symbols[0].Children[0].SelectionRange.Start); diff != "" {
+				t.Errorf("case name position mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRawStringNameWithCRLF(t *testing.T) {
+	t.Parallel()
+
+	const src = "package p\r\n\r\nimport \"testing\"\r\n\r\nfunc TestX(t *testing.T) {\r\n" +
+		"\tfor _, tt := range []struct{ name string }{{name: `multi\r\nline`}} {\r\n" +
+		"\t\tt.Run(tt.name, nil)\r\n\t}\r\n}\r\n"
+
+	got, err := Parse("x_test.go", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(got) != 1 || len(got[0].Children) != 1 {
+		t.Fatalf("Parse() = %+v, want one test function with one case", got)
+	}
+	if name := got[0].Children[0].Name; name != "multi\nline" {
+		t.Errorf("Parse() case name = %q, want %q", name, "multi\nline")
+	}
+}
+
+func TestAnalyzeStrict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("syntax errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := AnalyzeFile("testdata/syntax_error_test.go", Options{Strict: true})
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("AnalyzeFile() error = %v, want *ParseError", err)
+		}
+		if got, want := parseErr.Pos(), (Line{Line: 10, Character: 28, Offset: 176}); got != want {
+			t.Errorf("ParseError.Pos() = %+v, want %+v", got, want)
+		}
+		if got, want := len(parseErr.Errors), 4; got != want {
+			t.Errorf("len(ParseError.Errors) = %d, want %d", got, want)
+		}
+		if got, want := err.Error(), "testdata/syntax_error_test.go:11:29: expected operand, found '}' (and 3 more errors)"; got != want {
+			t.Errorf("ParseError.Error() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := AnalyzeFile("testdata/basic_table_test.go", Options{Strict: true}); err != nil {
+			t.Errorf("AnalyzeFile() error = %v", err)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := AnalyzeFile("testdata/non_existent.go", Options{Strict: true})
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("AnalyzeFile() error = %v, want fs.ErrNotExist", err)
+		}
+	})
+}
+
+func TestGroupCases(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import "testing"
+
+func TestGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+	}{
+		{name: "parse: ok / empty", category: "unit"},
+		{name: "parse: ok / full", category: "unit"},
+		{name: "plain"},
+		{name: "parse: fails", category: "integration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, nil)
+	}
+}
+`
+
+	testCase := func(name string) Symbol {
+		return Symbol{Name: name, Detail: "test case", Kind: SymbolKindStruct}
+	}
+	group := func(name string, children ...Symbol) Symbol {
+		return Symbol{Name: name, Detail: "test case group", Kind: SymbolKindNamespace, Children: children}
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []Symbol
+	}{
+		{
+			name: "no grouping",
+			want: []Symbol{
+				testCase("parse: ok / empty"),
+				testCase("parse: ok / full"),
+				testCase("plain"),
+				testCase("parse: fails"),
+			},
+		},
+		{
+			name: "delimiter",
+			opts: Options{GroupDelimiter: ":"},
+			want: []Symbol{
+				group("parse",
+					testCase("parse: ok / empty"),
+					testCase("parse: ok / full"),
+					testCase("parse: fails"),
+				),
+				testCase("plain"),
+			},
+		},
+		{
+			name: "nested groups",
+			opts: Options{GroupDelimiter: " / "},
+			want: []Symbol{
+				group("parse: ok",
+					testCase("parse: ok / empty"),
+					testCase("parse: ok / full"),
+				),
+				testCase("plain"),
+				testCase("parse: fails"),
+			},
+		},
+		{
+			name: "group field",
+			opts: Options{GroupFields: []string{"Category"}},
+			want: []Symbol{
+				group("unit",
+					testCase("parse: ok / empty"),
+					testCase("parse: ok / full"),
+				),
+				testCase("plain"),
+				group("integration", testCase("parse: fails")),
+			},
+		},
+		{
+			name: "group field and delimiter",
+			opts: Options{GroupFields: []string{"category"}, GroupDelimiter: ":"},
+			want: []Symbol{
+				group("unit", group("parse",
+					testCase("parse: ok / empty"),
+					testCase("parse: ok / full"),
+				)),
+				testCase("plain"),
+				group("integration", group("parse", testCase("parse: fails"))),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Analyze("groups_test.go", strings.NewReader(src), tt.opts)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			got := result.Symbols[0].Children
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Symbol{}, "Range", "SelectionRange", "Fields")); diff != "" {
+				t.Errorf("Analyze() groups mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupRange(t *testing.T) {
+	t.Parallel()
+
+	result, err := AnalyzeFile("testdata/typed_test_cases.go", Options{GroupDelimiter: ":"})
+	if err != nil {
+		t.Fatalf("AnalyzeFile() error = %v", err)
+	}
+	for _, fn := range result.Symbols {
+		for _, group := range fn.Children {
+			members := group.Children
+			if len(members) == 0 {
+				t.Fatalf("%s: %q is not a group", fn.Name, group.Name)
+			}
+			want := Range{Start: members[0].Range.Start, End: members[len(members)-1].Range.End}
+			if diff := cmp.Diff(want, group.Range); diff != "" {
+				t.Errorf("%s: range of group %q mismatch (-want +got):\n%s", fn.Name, group.Name, diff)
+			}
+		}
+	}
+}
+
+func TestGroupTables(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTables(t *testing.T) {
+	validCases := []struct{ name string }{
+		{name: "parse: one"},
+		{name: "parse: two"},
+	}
+	for _, tt := range validCases {
+		t.Run(tt.name, nil)
+	}
+
+	for _, n := range []int{1, 2} {
+		t.Run(fmt.Sprint(n), nil)
+	}
+
+	for _, tt := range []struct{ name string }{
+		{name: "inline"},
+	} {
+		t.Run(tt.name, nil)
+	}
+}
+
+func TestSingleTable(t *testing.T) {
+	errorCases := []struct{ name string }{
+		{name: "only"},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, nil)
+	}
+}
+`
+
+	testCase := func(name, detail string) Symbol {
+		return Symbol{Name: name, Detail: detail, Kind: SymbolKindStruct}
+	}
+	table := func(name string, children ...Symbol) Symbol {
+		return Symbol{Name: name, Detail: "test table", Kind: SymbolKindVariable, Children: children}
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want map[string][]Symbol
+	}{
+		{
+			name: "off",
+			want: map[string][]Symbol{
+				"TestTables": {
+					testCase("parse: one", "test case"),
+					testCase("parse: two", "test case"),
+					testCase("1", "generated test case"),
+					testCase("2", "generated test case"),
+					testCase("inline", "test case"),
+				},
+				"TestSingleTable": {testCase("only", "test case")},
+			},
+		},
+		{
+			name: "tables",
+			opts: Options{GroupTables: true},
+			want: map[string][]Symbol{
+				"TestTables": {
+					table("validCases", testCase("parse: one", "test case"), testCase("parse: two", "test case")),
+					testCase("1", "generated test case"),
+					testCase("2", "generated test case"),
+					table("range #1", testCase("inline", "test case")),
+				},
+				"TestSingleTable": {testCase("only", "test case")},
+			},
+		},
+		{
+			name: "tables and groups",
+			opts: Options{GroupTables: true, GroupDelimiter: ":"},
+			want: map[string][]Symbol{
+				"TestTables": {
+					table("validCases", Symbol{
+						Name:     "parse",
+						Detail:   "test case group",
+						Kind:     SymbolKindNamespace,
+						Children: []Symbol{testCase("parse: one", "test case"), testCase("parse: two", "test case")},
+					}),
+					testCase("1", "generated test case"),
+					testCase("2", "generated test case"),
+					table("range #1", testCase("inline", "test case")),
+				},
+				"TestSingleTable": {testCase("only", "test case")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Analyze("tables_test.go", strings.NewReader(src), tt.opts)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			got := map[string][]Symbol{}
+			for _, fn := range result.Symbols {
+				got[fn.Name] = fn.Children
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Symbol{}, "Range", "SelectionRange", "Fields")); diff != "" {
+				t.Errorf("Analyze() tables mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupTablesRange(t *testing.T) {
+	t.Parallel()
+
+	result, err := AnalyzeFile("testdata/multiple_tables_test.go", Options{GroupTables: true, AllTables: true})
+	if err != nil {
+		t.Fatalf("AnalyzeFile() error = %v", err)
+	}
+	var got []Range
+	for _, table := range result.Symbols[0].Children {
+		got = append(got, table.Range, table.SelectionRange)
+	}
+	want := []Range{
+		{Start: Line{Line: 6, Character: 11}, End: Line{Line: 11, Character: 2}},
+		{Start: Line{Line: 8, Character: 2}, End: Line{Line: 8, Character: 3}},
+		{Start: Line{Line: 14, Character: 11}, End: Line{Line: 19, Character: 2}},
+		{Start: Line{Line: 16, Character: 2}, End: Line{Line: 16, Character: 3}},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Line{}, "Offset")); diff != "" {
+		t.Errorf("AnalyzeFile() table ranges mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAllTables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts Options
+		want map[string][]string
+	}{
+		{
+			name: "run tables only",
+			want: map[string][]string{"TestKnownRunnerInLoop": {"run by a helper"}},
+		},
+		{
+			name: "all tables",
+			opts: Options{AllTables: true},
+			want: map[string][]string{
+				"TestLiteralsAreNotTables": {"bob", "alice", "x"},
+				"TestKnownRunnerInLoop":    {"run by a helper"},
+				"TestLoopWithoutSubtests":  {"go", "gofmt"},
+			},
+		},
+		{
+			name: "runner pattern",
+			opts: Options{Patterns: []Pattern{{Kind: PatternCall, Func: "listItems", Arg: 0}}},
+			want: map[string][]string{
+				"TestLiteralsAreNotTables": {"bob", "alice"},
+				"TestKnownRunnerInLoop":    {"run by a helper"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := AnalyzeFile("testdata/fake_tables_test.go", tt.opts)
+			if err != nil {
+				t.Fatalf("AnalyzeFile() error = %v", err)
+			}
+			got := map[string][]string{}
+			for _, fn := range result.Symbols {
+				for _, c := range fn.Children {
+					got[fn.Name] = append(got[fn.Name], c.Name)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("AnalyzeFile() case names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
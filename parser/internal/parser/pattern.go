@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+)
+
+// Pattern describes a user-defined test case pattern, written in the configuration file
+// for test helpers the built-in recognizers do not know
+type Pattern struct {
+	// Name identifies the pattern in Options.Recognizers. Empty means "<kind>:<func>".
+	Name string `json:"name,omitempty"`
+
+	// Kind is PatternCall, PatternConstructor or PatternWrapper
+	Kind string `json:"kind"`
+
+	// Func is the name of the called function, optionally qualified by a package or receiver
+	// (e.g. "runCases", "tdt.Run" or "s.check")
+	Func string `json:"func"`
+
+	// Arg is the 0-based position of the argument holding the table (PatternCall)
+	// or the test case name (PatternConstructor and PatternWrapper)
+	Arg int `json:"arg"`
+}
+
+// Pattern kinds
+const (
+	PatternCall        = "call"        // a table passed to a function, e.g. runCases(t, []Case{...})
+	PatternConstructor = "constructor" // table elements built by a function, e.g. []Case{newCase("name", ...)}
+	PatternWrapper     = "wrapper"     // a test case per call, e.g. check(t, "name", func(t *testing.T) {...})
+)
+
+// recognizerName returns the name of the recognizer of the pattern
+func (p Pattern) recognizerName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.Kind + ":" + p.Func
+}
+
+// validate checks the kind, function and argument position of the pattern
+func (p Pattern) validate() error {
+	if !slices.Contains([]string{PatternCall, PatternConstructor, PatternWrapper}, p.Kind) {
+		return fmt.Errorf("unknown pattern kind: %q", p.Kind)
+	}
+	if p.Func == "" {
+		return fmt.Errorf("pattern %q has no function", p.recognizerName())
+	}
+	if p.Arg < 0 {
+		return fmt.Errorf("pattern %q has a negative argument position", p.recognizerName())
+	}
+	return nil
+}
+
+// recognizer returns the recognizer of the pattern. The tables of call patterns are found
+// like those of the built-in recognizers, and linted.
+func (p Pattern) recognizer() Recognizer {
+	r := patternRecognizer{pattern: p}
+	if p.Kind == PatternCall {
+		return builtinRecognizer{name: r.Name(), match: r.matchCall}
+	}
+	return r
+}
+
+// patternRecognizer recognizes the test cases of constructor and wrapper patterns
+type patternRecognizer struct {
+	pattern Pattern
+}
+
+func (r patternRecognizer) Name() string {
+	return r.pattern.recognizerName()
+}
+
+func (r patternRecognizer) Recognize(fn *TestFunc) []Symbol {
+	var cases []Symbol
+	ast.Inspect(fn.Decl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CompositeLit:
+			// Pattern: []Case{newCase("name", ...), ...}
+			if r.pattern.Kind != PatternConstructor {
+				return true
+			}
+			for _, elt := range node.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value // map value
+				}
+				if call, ok := elt.(*ast.CallExpr); ok {
+					cases = append(cases, r.callCase(fn, call)...)
+				}
+			}
+		case *ast.CallExpr:
+			// Pattern: check(t, "name", func(t *testing.T) {...})
+			if r.pattern.Kind == PatternWrapper {
+				cases = append(cases, r.callCase(fn, node)...)
+			}
+		}
+		return true
+	})
+	return cases
+}
+
+// matchCall matches table literals passed to the function of the pattern.
+// Pattern: runCases(t, []Case{...})
+func (r patternRecognizer) matchCall(n ast.Node) []table {
+	arg := r.arg(n)
+	if compLit, ok := arg.(*ast.CompositeLit); ok {
+		return []table{{lit: compLit}}
+	}
+	return nil
+}
+
+// callCase returns the test case of a call to the function of the pattern, if its name is a string literal
+func (r patternRecognizer) callCase(fn *TestFunc, call *ast.CallExpr) []Symbol {
+	nameExpr := r.arg(call)
+	name, ok := extractStringLiteral(nameExpr)
+	if !ok || name == "" {
+		return nil
+	}
+	return []Symbol{fn.TestCase(name, call, nameExpr)}
+}
+
+// arg returns the argument at the position of the pattern if n is a call to its function
+func (r patternRecognizer) arg(n ast.Node) ast.Expr {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || r.pattern.Arg >= len(call.Args) || !matchFunc(r.pattern.Func, call.Fun) {
+		return nil
+	}
+	return call.Args[r.pattern.Arg]
+}
+
+// matchFunc reports whether fun is the named function. An unqualified name matches
+// functions and methods of that name with any qualifier.
+func matchFunc(name string, fun ast.Expr) bool {
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		return fun.Name == name
+	case *ast.SelectorExpr:
+		if fun.Sel.Name == name {
+			return true
+		}
+		qualifier, sel, ok := strings.Cut(name, ".")
+		ident, isIdent := fun.X.(*ast.Ident)
+		return ok && isIdent && ident.Name == qualifier && fun.Sel.Name == sel
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"unicode/utf8"
+)
+
+// Position encodings of Line.Character, named as in LSP's PositionEncodingKind
+const (
+	EncodingUTF8  = "utf-8"  // bytes
+	EncodingUTF16 = "utf-16" // UTF-16 code units, as used by VS Code
+	EncodingUTF32 = "utf-32" // Unicode code points
+)
+
+// DefaultPositionEncoding is the position encoding used when Options.PositionEncoding is empty
+const DefaultPositionEncoding = EncodingUTF16
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// validateEncoding checks that encoding is a known position encoding or empty
+func validateEncoding(encoding string) error {
+	switch encoding {
+	case "", EncodingUTF8, EncodingUTF16, EncodingUTF32:
+		return nil
+	}
+	return fmt.Errorf("unknown position encoding: %q", encoding)
+}
+
+// position converts a token position to an editor position.
+// Characters are counted in the configured encoding from the start of the line, not counting a
+// leading byte order mark, which editors do not show. Line breaks are LF or CRLF as go/scanner
+// sees them; the CR of a CRLF ends its line like a regular character.
+func (e *extractor) position(pos token.Pos) Line {
+	f := e.fset.File(pos)
+	if f == nil {
+		return Line{} // missing position in a partial syntax tree
+	}
+	if e.file != nil {
+		// Functions recovered from a syntax error are parsed from a copy of the file with the same offsets
+		offset := f.Offset(pos)
+		f = e.file
+		pos = f.Pos(min(offset, f.Size()))
+	}
+	p := f.PositionFor(pos, false) // ignore //line directives: positions refer to the edited file
+	lineStart := p.Offset - (p.Column - 1)
+	if e.src == nil || lineStart < 0 || p.Offset > len(e.src) {
+		return Line{Line: p.Line - 1, Character: p.Column - 1, Offset: p.Offset}
+	}
+
+	text := e.src[lineStart:p.Offset]
+	if lineStart == 0 {
+		text = bytes.TrimPrefix(text, utf8BOM)
+	}
+	return Line{Line: p.Line - 1, Character: countUnits(text, e.encoding()), Offset: p.Offset}
+}
+
+// encoding returns the configured position encoding
+func (e *extractor) encoding() string {
+	if e.opts.PositionEncoding == "" {
+		return DefaultPositionEncoding
+	}
+	return e.opts.PositionEncoding
+}
+
+// countUnits returns the length of text in the code units of encoding.
+// Invalid UTF-8 bytes count as one unit each, as editors show them as U+FFFD.
+func countUnits(text []byte, encoding string) int {
+	switch encoding {
+	case EncodingUTF8:
+		return len(text)
+	case EncodingUTF32:
+		return utf8.RuneCount(text)
+	}
+
+	n := 0
+	for len(text) > 0 {
+		r, size := utf8.DecodeRune(text)
+		if r >= 0x10000 {
+			n += 2 // surrogate pair
+		} else {
+			n++
+		}
+		text = text[size:]
+	}
+	return n
+}
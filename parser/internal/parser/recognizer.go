@@ -0,0 +1,290 @@
+package parser
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+)
+
+// Recognizer finds the test cases of a test function written in a particular pattern.
+// The built-in recognizers find test tables; Options.Patterns and Options.CustomRecognizers
+// can support other patterns, such as in-house test DSLs.
+type Recognizer interface {
+	// Name identifies the recognizer in Options.Recognizers
+	Name() string
+
+	// Recognize returns the test cases of the test function, typically created with TestFunc.TestCase
+	Recognize(fn *TestFunc) []Symbol
+}
+
+// TestFunc is a test function passed to recognizers
+type TestFunc struct {
+	Decl *ast.FuncDecl
+	Fset *token.FileSet
+	Info *types.Info // type information from Options.TypeInfo, nil if not available
+
+	e *extractor
+}
+
+// Range returns the range of a syntax node, in the position encoding of the analysis
+func (f *TestFunc) Range(node ast.Node) Range {
+	return f.e.nodeRange(node)
+}
+
+// TestCase returns a test case symbol spanning node, whose name is held by nameNode
+func (f *TestFunc) TestCase(name string, node, nameNode ast.Node) Symbol {
+	return f.e.createTestCaseSymbol(name, node, nameNode, nil)
+}
+
+// builtinRecognizer finds test tables of one form. Unlike the cases found by other recognizers,
+// the test cases of its tables are linted.
+type builtinRecognizer struct {
+	name string
+	// match returns the table literals of a node with the variables they are assigned to
+	match func(n ast.Node) []table
+}
+
+var builtinRecognizers = []builtinRecognizer{
+	{name: RecognizerSlice, match: func(n ast.Node) []table { return matchAssign(n, false) }},
+	{name: RecognizerMap, match: func(n ast.Node) []table { return matchAssign(n, true) }},
+	{name: RecognizerRangeInline, match: matchRangeInline},
+	{name: RecognizerVarDecl, match: matchVarDecl},
+}
+
+func (r builtinRecognizer) Name() string {
+	return r.name
+}
+
+func (r builtinRecognizer) Recognize(fn *TestFunc) []Symbol {
+	var cases []Symbol
+	for _, t := range r.tables(fn) {
+		cases = append(cases, t.cases...)
+	}
+	return cases
+}
+
+// tables returns the test tables in the body of the test function
+func (r builtinRecognizer) tables(fn *TestFunc) []table {
+	var tables []table
+	ast.Inspect(fn.Decl.Body, func(n ast.Node) bool {
+		for _, t := range r.match(n) {
+			if t.ident != nil {
+				// Pattern: tests := ...; runTableTests(t, tests)
+				t.nameField, _ = fn.e.passedToRunner(fn.Decl, t.ident.Name)
+			}
+			fn.e.extractTable(&t, fn.Decl)
+			tables = append(tables, t)
+		}
+		return true
+	})
+	return tables
+}
+
+// matchAssign matches tables assigned to a variable, of map type if isMap or of another type otherwise.
+// Pattern: tests := []struct{...}{...}, tests := Tests{...}, tests := map[string]struct{...}{...}
+func matchAssign(n ast.Node, isMap bool) []table {
+	assign, ok := n.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return nil
+	}
+	compLit, ok := assign.Rhs[0].(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	if _, ok := compLit.Type.(*ast.MapType); ok != isMap {
+		return nil
+	}
+	var ident *ast.Ident
+	if len(assign.Lhs) == 1 {
+		ident, _ = assign.Lhs[0].(*ast.Ident)
+	}
+	return []table{{lit: compLit, ident: ident}}
+}
+
+// matchRangeInline matches tables ranged over directly.
+// Pattern: for _, tc := range []struct{...}{...}
+func matchRangeInline(n ast.Node) []table {
+	rangeStmt, ok := n.(*ast.RangeStmt)
+	if !ok {
+		return nil
+	}
+	if compLit, ok := rangeStmt.X.(*ast.CompositeLit); ok {
+		return []table{{lit: compLit}}
+	}
+	return nil
+}
+
+// matchVarDecl matches tables declared with var.
+// Pattern: var tests = []struct{...}{...}
+func matchVarDecl(n ast.Node) []table {
+	declStmt, ok := n.(*ast.DeclStmt)
+	if !ok {
+		return nil
+	}
+	genDecl, ok := declStmt.Decl.(*ast.GenDecl)
+	if !ok || genDecl.Tok != token.VAR {
+		return nil
+	}
+	var tables []table
+	for _, spec := range genDecl.Specs {
+		if valueSpec, ok := spec.(*ast.ValueSpec); ok && len(valueSpec.Values) == 1 {
+			if compLit, ok := valueSpec.Values[0].(*ast.CompositeLit); ok {
+				tables = append(tables, table{lit: compLit, ident: valueSpec.Names[0]})
+			}
+		}
+	}
+	return tables
+}
+
+// validateRecognizers checks the patterns, that the patterns and custom recognizers have
+// distinct names and that the enabled recognizers exist
+func (o Options) validateRecognizers() error {
+	names := slices.Clone(AllRecognizers)
+	addName := func(name string) error {
+		if name == "" || slices.Contains(names, name) {
+			return fmt.Errorf("invalid or duplicate recognizer name: %q", name)
+		}
+		names = append(names, name)
+		return nil
+	}
+	for _, p := range o.Patterns {
+		if err := p.validate(); err != nil {
+			return err
+		}
+		if err := addName(p.recognizerName()); err != nil {
+			return err
+		}
+	}
+	for _, r := range o.CustomRecognizers {
+		if err := addName(r.Name()); err != nil {
+			return err
+		}
+	}
+	for _, name := range o.Recognizers {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("unknown recognizer: %q", name)
+		}
+	}
+	return nil
+}
+
+// enabled reports whether the named recognizer is enabled
+func (e *extractor) enabled(recognizer string) bool {
+	return e.opts.Recognizers == nil || slices.Contains(e.opts.Recognizers, recognizer)
+}
+
+// recognizers returns the recognizers of the analysis: the built-in ones, those of the
+// configured patterns and the custom ones
+func (e *extractor) recognizers() []Recognizer {
+	var recognizers []Recognizer
+	for _, r := range builtinRecognizers {
+		recognizers = append(recognizers, r)
+	}
+	recognizers = append(recognizers, runnerRecognizer{}, loopRecognizer{})
+	for _, p := range e.opts.Patterns {
+		recognizers = append(recognizers, p.recognizer())
+	}
+	return append(recognizers, e.opts.CustomRecognizers...)
+}
+
+// tableRecognizer is implemented by recognizers finding test tables, whose test cases are linted
+type tableRecognizer interface {
+	tables(fn *TestFunc) []table
+}
+
+// recognize runs the enabled recognizers on a test function. It returns the test tables found
+// and all test cases, in source order. Only the cases of tables run as subtests are outlined,
+// unless Options.AllTables is set. The tables of benchmarks are only those run with b.Run,
+// as benchmarks often use tables of inputs for a single measurement.
+func (e *extractor) recognize(decl *ast.FuncDecl, benchmark bool) ([]table, []Symbol) {
+	fn := &TestFunc{Decl: decl, Fset: e.fset, Info: e.opts.TypeInfo, e: e}
+
+	var tables []table
+	var cases []Symbol
+	for _, r := range e.recognizers() {
+		if !e.enabled(r.Name()) {
+			continue
+		}
+		tr, ok := r.(tableRecognizer)
+		if !ok {
+			recognized := r.Recognize(fn)
+			e.attachComments(recognized)
+			cases = append(cases, recognized...)
+			continue
+		}
+		found := tr.tables(fn)
+		if benchmark {
+			found = slices.DeleteFunc(found, func(t table) bool {
+				return !e.isRunTable(decl, t)
+			})
+		}
+		for i := range found {
+			t := &found[i]
+			// Literals like `want := []Item{{Name: "x"}}` are not test tables, but they are still linted.
+			// The loop over a table may be missing from the partial syntax tree of a function with syntax errors.
+			t.outlined = e.opts.AllTables || benchmark || e.isRunTable(decl, *t) || e.hasSyntaxError(decl.Body)
+			if t.outlined {
+				e.attachComments(t.cases)
+				cases = append(cases, t.cases...)
+			}
+		}
+		tables = append(tables, found...)
+	}
+
+	slices.SortStableFunc(tables, func(a, b table) int {
+		return cmp.Compare(a.lit.Pos(), b.lit.Pos())
+	})
+	tables = slices.CompactFunc(tables, func(a, b table) bool {
+		return a.lit == b.lit
+	})
+	slices.SortStableFunc(cases, compareCases)
+	// A node found by several recognizers is a single test case
+	cases = slices.CompactFunc(cases, func(a, b Symbol) bool {
+		return a.Range == b.Range && a.Name == b.Name
+	})
+	return tables, cases
+}
+
+// indexes reports whether node contains an index expression on a table, as in tests[i]
+func indexes(node ast.Node, isTable func(ast.Expr) bool) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if index, ok := n.(*ast.IndexExpr); ok && isTable(index.X) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// compareCases orders test cases by position
+func compareCases(a, b Symbol) int {
+	return cmp.Compare(a.Range.Start.Offset, b.Range.Start.Offset)
+}
+
+// isRunTable reports whether the elements of a table are run as subtests: the table variable or
+// the inline literal is ranged over, or the table variable is indexed in a for loop, in a loop running
+// subtests (see runsCases), or is passed to the function of a pattern or to a helper along with a *testing.T
+func (e *extractor) isRunTable(decl *ast.FuncDecl, t table) bool {
+	isTable := func(expr ast.Expr) bool {
+		return expr == t.lit || t.ident != nil && isIdent(expr, t.ident.Name)
+	}
+	params := testingParams(decl)
+	found := false
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.RangeStmt:
+			found = found || isTable(n.X) && e.runsCases(n.Body, params)
+		case *ast.ForStmt:
+			// Pattern: for i := 0; i < len(tests); i++ { t.Run(tests[i].name, ...) }
+			found = found || indexes(n.Body, isTable) && e.runsCases(n.Body, params)
+		case *ast.CallExpr:
+			found = found || slices.ContainsFunc(n.Args, isTable) && (e.matchesPattern(n) || passesParam(n, params))
+		}
+		return !found
+	})
+	return found
+}
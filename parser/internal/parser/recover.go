@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// topLevelKeywords start the lines of top-level declarations in gofmt-formatted source
+var topLevelKeywords = [][]byte{[]byte("func "), []byte("func("), []byte("type "), []byte("var "), []byte("const "), []byte("import ")}
+
+// recoverFunctions returns the function declarations of a file with syntax errors.
+// go/parser often fails to recover from an error inside a function, dropping the following
+// functions or merging them into a broken one. Functions whose declaration does not end
+// before the next top-level declaration are therefore parsed again from a copy of the source
+// in which everything before the function but the package clause is blanked out, so that
+// their positions stay the same.
+func recoverFunctions(fset *token.FileSet, file *ast.File, src []byte) []ast.Decl {
+	tf := fset.File(file.FileStart)
+	if tf == nil || file.Name == nil || !file.Name.End().IsValid() || tf.Size() != len(src) {
+		return file.Decls
+	}
+
+	parsed := map[int]*ast.FuncDecl{} // by start offset
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+			parsed[tf.Offset(funcDecl.Pos())] = funcDecl
+		}
+	}
+
+	starts := topLevelStarts(src)
+	var decls []ast.Decl
+	for i, start := range starts {
+		if !bytes.HasPrefix(src[start:], []byte("func")) {
+			continue
+		}
+		end := len(src)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+
+		funcDecl, ok := parsed[start]
+		if !ok || !funcDecl.End().IsValid() || tf.Offset(funcDecl.End()) > end {
+			funcDecl = parseFunction(fset, tf.Name(), src, tf.Offset(file.Name.End()), start, end)
+		}
+		if funcDecl != nil {
+			decls = append(decls, funcDecl)
+		}
+	}
+	return decls
+}
+
+// topLevelStarts returns the offsets of the lines starting a top-level declaration
+func topLevelStarts(src []byte) []int {
+	var starts []int
+	for offset := 0; offset < len(src); {
+		line := src[offset:]
+		for _, keyword := range topLevelKeywords {
+			if bytes.HasPrefix(line, keyword) {
+				starts = append(starts, offset)
+				break
+			}
+		}
+		i := bytes.IndexByte(line, '\n')
+		if i < 0 {
+			break
+		}
+		offset += i + 1
+	}
+	return starts
+}
+
+// parseFunction parses the function declaration at src[start:end] on its own,
+// keeping the package clause that ends at packageEnd. The source is cut at end, so that an
+// unterminated function ends there rather than at the end of the file.
+func parseFunction(fset *token.FileSet, filename string, src []byte, packageEnd, start, end int) *ast.FuncDecl {
+	masked := bytes.Clone(src[:end])
+	for i := packageEnd; i < start; i++ {
+		if masked[i] != '\n' {
+			masked[i] = ' '
+		}
+	}
+
+	file, _ := parser.ParseFile(fset, filename, masked, parser.AllErrors) // errors are reported from the whole file
+	if file == nil {
+		return nil
+	}
+	tf := fset.File(file.FileStart)
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && tf.Offset(funcDecl.Pos()) == start {
+			return funcDecl
+		}
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// runnerRecognizer finds tables passed directly to helpers taking *testing.T, such as
+// runTableTests(t, []struct{...}{...})
+type runnerRecognizer struct{}
+
+func (runnerRecognizer) Name() string {
+	return RecognizerRunner
+}
+
+func (r runnerRecognizer) Recognize(fn *TestFunc) []Symbol {
+	var cases []Symbol
+	for _, t := range r.tables(fn) {
+		cases = append(cases, t.cases...)
+	}
+	return cases
+}
+
+func (runnerRecognizer) tables(fn *TestFunc) []table {
+	var tables []table
+	for _, call := range runnerCalls(fn.Decl) {
+		for i, arg := range call.Args {
+			if compLit, ok := arg.(*ast.CompositeLit); ok {
+				t := table{lit: compLit, nameField: fn.e.helperNameField(call, i)}
+				fn.e.extractTable(&t, fn.Decl)
+				tables = append(tables, t)
+			}
+		}
+	}
+	return tables
+}
+
+// runnerCalls returns the calls in a test function that pass a *testing.T (or *testing.B, testing.TB)
+// parameter of the function or of its function literals to another function, other than its methods
+func runnerCalls(decl *ast.FuncDecl) []*ast.CallExpr {
+	params := testingParams(decl)
+	var calls []*ast.CallExpr
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && passesParam(call, params) {
+			calls = append(calls, call)
+		}
+		return true
+	})
+	return calls
+}
+
+// testingParams returns the names of the *testing.T (or *testing.B, testing.TB) parameters of a
+// test function and of its function literals
+func testingParams(decl *ast.FuncDecl) map[string]bool {
+	params := map[string]bool{}
+	addParams := func(fields *ast.FieldList) {
+		for _, field := range fields.List {
+			if isTestingType(field.Type) {
+				for _, name := range field.Names {
+					params[name.Name] = true
+				}
+			}
+		}
+	}
+	addParams(decl.Type.Params)
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if funcLit, ok := n.(*ast.FuncLit); ok {
+			addParams(funcLit.Type.Params)
+		}
+		return true
+	})
+	return params
+}
+
+// passesParam reports whether call passes one of the named parameters as an argument
+func passesParam(call *ast.CallExpr, params map[string]bool) bool {
+	return slices.ContainsFunc(call.Args, func(arg ast.Expr) bool {
+		ident, ok := arg.(*ast.Ident)
+		return ok && params[ident.Name]
+	})
+}
+
+// isTestingType reports whether a type expression is *testing.T, *testing.B or testing.TB
+func isTestingType(expr ast.Expr) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		sel, ok := star.X.(*ast.SelectorExpr)
+		return ok && isIdent(sel.X, "testing") && (sel.Sel.Name == "T" || sel.Sel.Name == "B")
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && isIdent(sel.X, "testing") && sel.Sel.Name == "TB"
+}
+
+// isIdent reports whether expr is the identifier name
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// runsCases reports whether a loop body runs subtests: it calls Run, the function of a pattern, or
+// a helper of the package that is passed one of the *testing.T parameters and calls Run itself
+func (e *extractor) runsCases(body *ast.BlockStmt, params map[string]bool) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			found = isRunCall(call) || e.matchesPattern(call) || passesParam(call, params) && e.isRunHelper(call)
+		}
+		return !found
+	})
+	return found
+}
+
+// matchesPattern reports whether call calls the function of a pattern
+func (e *extractor) matchesPattern(call *ast.CallExpr) bool {
+	return slices.ContainsFunc(e.opts.Patterns, func(p Pattern) bool {
+		return matchFunc(p.Func, call.Fun)
+	})
+}
+
+// isRunHelper reports whether call calls a function of the package that takes a *testing.T (or
+// *testing.B, testing.TB) and calls Run, as in `func runCase(t *testing.T, tt testCase) { t.Run(...) }`
+func (e *extractor) isRunHelper(call *ast.CallExpr) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return false // helpers of other packages are only known through patterns
+	}
+	decl := e.packageFunc(ident.Name)
+	if decl == nil || decl.Body == nil {
+		return false
+	}
+	takesTesting := slices.ContainsFunc(decl.Type.Params.List, func(field *ast.Field) bool {
+		return isTestingType(field.Type)
+	})
+	return takesTesting && callsRun(decl.Body)
+}
+
+// passedToRunner reports whether the named table variable is passed to a helper taking *testing.T.
+// It returns the name field of the table found in the helper, if any.
+func (e *extractor) passedToRunner(decl *ast.FuncDecl, name string) (nameField string, ok bool) {
+	for _, call := range runnerCalls(decl) {
+		for i, arg := range call.Args {
+			if isIdent(arg, name) {
+				return e.helperNameField(call, i), true
+			}
+		}
+	}
+	return "", false
+}
+
+// helperNameField returns the field that a helper of the package called by call passes to t.Run
+// as the test name of the elements of its argument at index, as in
+// `for _, tc := range tests { t.Run(tc.label, ...) }`. It returns an empty string if the helper
+// is not found or does not range over the argument with t.Run.
+func (e *extractor) helperNameField(call *ast.CallExpr, index int) string {
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return "" // helpers of other packages, e.g. testutil.Run(t, cases, fn)
+	}
+	decl := e.packageFunc(ident.Name)
+	if decl == nil || decl.Body == nil {
+		return ""
+	}
+	params := paramNames(decl)
+	if index >= len(params) || params[index] == "" {
+		return ""
+	}
+
+	var field string
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		rangeStmt, ok := n.(*ast.RangeStmt)
+		if field != "" || !ok || !isIdent(rangeStmt.X, params[index]) {
+			return field == ""
+		}
+		value, ok := rangeStmt.Value.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		ast.Inspect(rangeStmt.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if field != "" || !ok || len(call.Args) == 0 {
+				return field == ""
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Run" {
+				return true
+			}
+			if arg, ok := call.Args[0].(*ast.SelectorExpr); ok && isIdent(arg.X, value.Name) {
+				field = arg.Sel.Name
+			}
+			return true
+		})
+		return true
+	})
+	return field
+}
+
+// packageFunc returns the function of the analyzed file with the given name, or else the function
+// of the other Go files in Options.PackageDir
+func (e *extractor) packageFunc(name string) *ast.FuncDecl {
+	if decl := e.funcs[name]; decl != nil {
+		return decl
+	}
+	if e.packageFuncs == nil {
+		e.packageFuncs = loadPackageFuncs(e.opts.PackageDir, e.file.Name())
+	}
+	return e.packageFuncs[name]
+}
+
+// loadPackageFuncs parses the Go files of dir other than the named file and returns their functions.
+// Files that cannot be read or parsed are skipped, as the helpers are only used to find name fields.
+func loadPackageFuncs(dir, filename string) map[string]*ast.FuncDecl {
+	funcs := map[string]*ast.FuncDecl{}
+	if dir == "" {
+		return funcs
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return funcs
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || sameFile(path, filename) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil {
+				funcs[funcDecl.Name.Name] = funcDecl
+			}
+		}
+	}
+	return funcs
+}
+
+// sameFile reports whether two paths refer to the same file
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	return err == nil && os.SameFile(infoA, infoB)
+}
@@ -0,0 +1,36 @@
+package main_test
+
+import (
+	"runtime"
+	"testing"
+)
+
+type pathCase struct {
+	name string
+	path string
+	want string
+}
+
+func TestAppendedCases(t *testing.T) {
+	tests := []pathCase{
+		{name: "relative", path: "a/b", want: "a/b"},
+	}
+	tests = append(tests, pathCase{name: "absolute", path: "/a", want: "/a"})
+	if runtime.GOOS == "windows" {
+		tests = append(tests,
+			pathCase{name: "drive letter", path: `C:\a`, want: `C:\a`},
+			pathCase{name: "UNC path", path: `\\host\share`, want: `\\host\share`},
+		)
+	} else {
+		tests = append(tests, pathCase{name: "backslash", path: `a\b`, want: `a\b`})
+	}
+	tests = append(tests, []pathCase{
+		{name: "spread", path: "", want: "."},
+	}...)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.path
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package main_test
+
+import "testing"
+
+func TestCommentNames(t *testing.T) {
+	tests := []struct {
+		input   int
+		want    int
+		wantErr bool
+	}{
+		// rejects negative input
+		{-1, 0, true},
+		{0, 0, false}, // accepts zero
+		{1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			_ = tt
+		})
+	}
+}
+
+func TestCommentDetails(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		// Leading comments may span
+		// several lines.
+		{name: "multi-line", input: "a"},
+		{name: "trailing", input: "b"}, // comments are added to the detail
+
+		{name: "plain", input: "c"},
+		// documented but unnamed
+		{input: "d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.input
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main_test
+
+import "testing"
+
+type parseCase struct {
+	name    string
+	input   string
+	want    int
+	wantErr bool
+}
+
+func ok(name, input string, want int) parseCase {
+	return parseCase{name: name, input: input, want: want}
+}
+
+func fails(name, input string) parseCase {
+	return parseCase{name: name, input: input, wantErr: true}
+}
+
+type caseBuilder struct {
+	desc  string
+	input string
+}
+
+func newCase(desc string) *caseBuilder {
+	return &caseBuilder{desc: desc}
+}
+
+func (b *caseBuilder) With(input string) *caseBuilder {
+	b.input = input
+	return b
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []parseCase{
+		ok("parses int", "1", 1),
+		fails("rejects empty", ""),
+		{name: "literal case", input: "2", want: 2},
+		undefined("not confirmed"),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {})
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	tests := []*caseBuilder{
+		newCase("built").With("1"),
+		newCase("built again"),
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {})
+	}
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import "testing"
+
+func TestDuplicateCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		setup   func()
+		want    int
+		wantErr bool
+	}{
+		{name: "one", input: "1", want: 1},
+		{name: "also one", input: "1", want: 1},
+		{name: "one again", input: "1", want: 2, wantErr: true},
+		{name: "formatted", input: "2", setup: func() {}, want: 2},
+		{name: "reformatted", input: "2", setup: func() {
+		}, want: 2},
+		{name: "other setup", input: "2", setup: nil, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt
+		})
+	}
+}
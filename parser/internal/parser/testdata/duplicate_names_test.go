@@ -0,0 +1,34 @@
+package main_test
+
+import "testing"
+
+func TestDuplicateNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input int
+	}{
+		{name: "a b", input: 1},
+		{name: "a_b", input: 2},
+		{name: "a b", input: 3},
+		{name: "", input: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.input
+		})
+	}
+}
+
+func TestEmptyMapKey(t *testing.T) {
+	tests := map[string]int{
+		"":    0,
+		"one": 1,
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			_ = value
+		})
+	}
+}
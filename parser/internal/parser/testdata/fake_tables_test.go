@@ -0,0 +1,74 @@
+package main_test
+
+import (
+	"os/exec"
+	"testing"
+	"unicode/utf8"
+)
+
+type Item struct {
+	Name string
+}
+
+type User struct {
+	Name string
+	Age  int
+}
+
+func TestLiteralsAreNotTables(t *testing.T) {
+	users := []User{{Name: "bob", Age: 30}, {Name: "alice", Age: 25}}
+	want := []Item{{Name: "x"}}
+	blank := []Item{{Name: ""}}
+
+	got := listItems(users)
+	if len(listItems(nil)) != len(blank)-1 {
+		t.Errorf("listItems(nil) is not empty")
+	}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("got %v, want %v", got[i], w)
+		}
+	}
+}
+
+func TestKnownRunnerInLoop(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+	}{
+		{name: "run by a helper", in: 1},
+	}
+	for _, tt := range tests {
+		runCase(t, tt.name, tt.in)
+	}
+}
+
+func TestLoopWithoutSubtests(t *testing.T) {
+	tools := []Item{{Name: "go"}, {Name: "gofmt"}}
+	for _, tool := range tools {
+		if err := exec.Command(tool.Name, "-h").Run(); err != nil {
+			t.Log(err)
+		}
+		if runes(tool.Name) == 0 {
+			t.Errorf("empty tool name")
+		}
+	}
+}
+
+func listItems(users []User) []Item {
+	items := make([]Item, len(users))
+	for i, u := range users {
+		items[i] = Item{Name: u.Name}
+	}
+	return items
+}
+
+func runCase(t *testing.T, name string, in int) {
+	t.Run(name, func(t *testing.T) {
+		_ = in
+	})
+}
+
+func runes(s string) int {
+	return utf8.RuneCountInString(s)
+}
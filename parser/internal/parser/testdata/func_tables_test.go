@@ -0,0 +1,37 @@
+package main_test
+
+import "testing"
+
+func TestFuncMap(t *testing.T) {
+	for name, fn := range map[string]func(*testing.T){
+		"empty": testEmpty,
+		"big":   testBig,
+		"inline": func(t *testing.T) {
+			t.Log("inline")
+		},
+		"other package": testing.Short,
+	} {
+		t.Run(name, fn)
+	}
+}
+
+func TestFuncSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T)
+	}{
+		{name: "empty", fn: testEmpty},
+		{"big", testBig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.fn)
+	}
+}
+
+func testEmpty(t *testing.T) {
+	t.Log("empty")
+}
+
+func testBig(t *testing.T) {
+	t.Log("big")
+}
@@ -0,0 +1,85 @@
+package main_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func BenchmarkSizes(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("size=%d", n), func(b *testing.B) {
+			_ = n
+		})
+	}
+}
+
+func BenchmarkCrossProduct(b *testing.B) {
+	algorithms := []string{"fast", "slow"}
+	for _, algo := range algorithms {
+		for _, size := range []int{1, 2} {
+			b.Run(algo+"/"+strconv.Itoa(size), func(b *testing.B) {})
+		}
+	}
+}
+
+func BenchmarkTable(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		n    int
+	}{
+		{name: "small", n: 1},
+		{name: "large", n: 1000},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {})
+	}
+
+	inputs := []struct {
+		name string
+	}{
+		{name: "not a sub-benchmark"},
+	}
+	for _, in := range inputs {
+		_ = in
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	for i := range 3 {
+		t.Run(fmt.Sprint("shard ", i), func(t *testing.T) {
+			for _, mode := range []string{"read", "write"} {
+				t.Run(mode, func(t *testing.T) {}) // nested subtests are not outlined
+			}
+		})
+	}
+	for i, ratio := range []float64{0.5, 1} {
+		t.Run(fmt.Sprintf("#%d ratio=%.1f", i, ratio), func(t *testing.T) {})
+	}
+}
+
+func TestNotEvaluated(t *testing.T) {
+	for _, n := range sizes() {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {})
+	}
+	for _, n := range []int{1, 2} {
+		t.Run(fmt.Sprintf("%d", n*2), func(t *testing.T) {})
+	}
+	for _, d := range []time.Duration{100, 200} {
+		t.Run(fmt.Sprint(d), func(t *testing.T) {}) // 100ns, 200ns
+	}
+	for _, s := range []Size{1, 2} {
+		t.Run(fmt.Sprint(s), func(t *testing.T) {}) // 1KB, 2KB
+	}
+}
+
+type Size int
+
+func (s Size) String() string {
+	return strconv.Itoa(int(s)) + "KB"
+}
+
+func sizes() []int {
+	return []int{1}
+}
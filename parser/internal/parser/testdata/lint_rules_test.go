@@ -0,0 +1,32 @@
+package testdata
+
+import "testing"
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		input int
+	}{
+		{name: "valid: one", input: 1},
+		{name: "valid: two", input: 2},
+		{name: "no prefix", input: 3},
+		{"invalid: positional", 4},
+		{name: " padded ", input: 5},
+		{input: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.input
+		})
+	}
+}
+
+func TestUnusedTable(t *testing.T) {
+	cases := []struct {
+		name string
+	}{
+		{name: "never run"},
+	}
+	_ = cases
+}
@@ -0,0 +1,42 @@
+package main_test
+
+import "testing"
+
+type testCase struct {
+	name  string
+	input int
+	want  int
+}
+
+func newCase(name string, input, want int) testCase {
+	return testCase{name: name, input: input, want: want}
+}
+
+func runCases(t *testing.T, cases []testCase) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {})
+	}
+}
+
+func check(t *testing.T, name string, f func(t *testing.T)) {
+	t.Run(name, f)
+}
+
+func TestRunCases(t *testing.T) {
+	runCases(t, []testCase{
+		{name: "passed table", input: 1, want: 1},
+		{name: "another case", input: 2, want: 2},
+	})
+}
+
+func TestConstructor(t *testing.T) {
+	runCases(t, []testCase{
+		newCase("constructed", 1, 1),
+		newCase("constructed too", 2, 2),
+	})
+}
+
+func TestWrapper(t *testing.T) {
+	check(t, "first check", func(t *testing.T) {})
+	check(t, "second check", func(t *testing.T) {})
+}
@@ -0,0 +1,18 @@
+package main_test
+
+import "testing"
+
+func TestCaseInsensitiveRun(t *testing.T) {
+	tests := []struct {
+		NAME string
+		Name string
+		name string
+	}{
+		{NAME: "uppercase NAME"},
+		{Name: "mixed case Name"},
+		{name: "lowercase name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.NAME+tt.Name+tt.name, func(t *testing.T) {})
+	}
+}
@@ -0,0 +1,28 @@
+package main_test
+
+import "testing"
+
+func TestMultipleTablesRun(t *testing.T) {
+	// First test table
+	tests1 := []struct {
+		name string
+	}{
+		{name: "table1-test1"},
+		{name: "table1-test2"},
+	}
+
+	// Second test table
+	tests2 := []struct {
+		name string
+	}{
+		{name: "table2-test1"},
+		{name: "table2-test2"},
+	}
+
+	for _, tt := range tests1 {
+		t.Run(tt.name, func(t *testing.T) {})
+	}
+	for _, tt := range tests2 {
+		t.Run(tt.name, func(t *testing.T) {})
+	}
+}
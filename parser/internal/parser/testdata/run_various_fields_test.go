@@ -0,0 +1,20 @@
+package main_test
+
+import "testing"
+
+func TestVariousFieldsRun(t *testing.T) {
+	tests := []struct {
+		description string
+		title       string
+		scenario    string
+		testName    string
+	}{
+		{description: "description field"},
+		{title: "title field"},
+		{scenario: "scenario field"},
+		{testName: "testName field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description+tt.title+tt.scenario+tt.testName, func(t *testing.T) {})
+	}
+}
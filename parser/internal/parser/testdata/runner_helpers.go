@@ -0,0 +1,13 @@
+package main_test
+
+import "testing"
+
+// runTableTests runs the test cases of a table named by their label field
+func runTableTests(t *testing.T, tests []labeledCase) {
+	t.Helper()
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			_ = tc.in
+		})
+	}
+}
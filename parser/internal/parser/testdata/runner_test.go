@@ -0,0 +1,57 @@
+package main_test
+
+import (
+	"testing"
+
+	"example.com/testutil"
+)
+
+type labeledCase struct {
+	label string
+	in    int
+}
+
+func TestRunnerVariable(t *testing.T) {
+	tests := []labeledCase{
+		{label: "labeled by helper", in: 1},
+		{label: "in another file", in: 2},
+	}
+	runTableTests(t, tests)
+}
+
+func TestRunnerInline(t *testing.T) {
+	runScenarios(t, []struct {
+		scenarioName string
+		in           int
+	}{
+		{scenarioName: "inline table", in: 1},
+		{scenarioName: "same file helper", in: 2},
+	})
+}
+
+func TestExternalRunner(t *testing.T) {
+	testutil.Run(t, []struct {
+		name string
+		in   int
+	}{
+		{name: "default name field", in: 1},
+	}, func(t *testing.T, in int) {})
+}
+
+func TestNotPassed(t *testing.T) {
+	unused := []struct {
+		name string
+	}{
+		{name: "never run"},
+	}
+	_ = unused
+}
+
+func runScenarios[T any](t *testing.T, scenarios []struct {
+	scenarioName string
+	in           T
+}) {
+	for _, s := range scenarios {
+		t.Run(s.scenarioName, func(t *testing.T) {})
+	}
+}
@@ -0,0 +1,26 @@
+package testdata
+
+import "testing"
+
+func TestMidEdit(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+	}{
+		{name: "complete", in: 1},
+		{name: "being typed", in: },
+		{name: "after the error", in: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.in
+		})
+	}
+}
+
+func TestAfterBrokenFunction(t *testing.T) {
+	for _, tt := range []struct{ name string }{{name: "still found"}} {
+		t.Run(tt.name, func(t *testing.T) {})
+	}
+}
@@ -0,0 +1,20 @@
+package testdata
+
+import "testing"
+
+func TestUnicodeNames(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{want: "日本語", name: "日本語の後の名前"},
+		{want: "🎉", name: "emoji 🎉 after"},
+		{want: "ascii", name: "ascii"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.want
+		})
+	}
+}
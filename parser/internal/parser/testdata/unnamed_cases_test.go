@@ -0,0 +1,52 @@
+package main_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestIndexNames(t *testing.T) {
+	tests := []struct {
+		input int
+		want  int
+	}{
+		{input: 1, want: 2},
+		{input: 2, want: 4},
+	}
+
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if tt.input*2 != tt.want {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestSprintIndexNames(t *testing.T) {
+	for i, tt := range []struct{ in, want string }{
+		{"a", "A"},
+		{"b", "B"},
+	} {
+		t.Run(fmt.Sprint("case-", i), func(t *testing.T) {
+			_ = tt
+		})
+	}
+}
+
+func TestPlaceholderNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input int
+	}{
+		{name: "first", input: 1},
+		{input: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.input
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package main_test
+
+import "testing"
+
+func TestRunTable(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+	}{
+		{name: "run as a subtest", in: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = tt.in
+		})
+	}
+}
+
+func TestIndexLoop(t *testing.T) {
+	tests := []struct {
+		name string
+	}{
+		{name: "run by index"},
+	}
+	for i := 0; i < len(tests); i++ {
+		t.Run(tests[i].name, func(t *testing.T) {})
+	}
+}
+
+func TestRangedWithoutRun(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+	}{
+		{name: "checked in the loop", in: 1},
+	}
+	for _, tt := range tests {
+		if tt.in < 0 {
+			t.Errorf("%s: negative input", tt.name)
+		}
+	}
+}
+
+func TestNeverRanged(t *testing.T) {
+	cases := []struct {
+		name string
+	}{
+		{name: "declared only"},
+	}
+	_ = cases
+}
+
+func TestInlineRangeWithoutRun(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+	}{
+		{name: "inline without subtests"},
+	} {
+		t.Log(tt.name)
+	}
+}
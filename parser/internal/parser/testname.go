@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SubtestName returns the name go test reports for a subtest created with t.Run(name, ...).
+// It mirrors the rewrite applied by the testing package: spaces become underscores
+// and non-printable characters are replaced by their Go escape sequences.
+func SubtestName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteByte('_')
+		case !strconv.IsPrint(r):
+			s := strconv.QuoteRune(r)
+			b.WriteString(s[1 : len(s)-1])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SubtestNames returns the go test names for sibling subtests, in order.
+// Like the testing package, it appends a "#NN" suffix to names that are already taken
+// and names empty subtests "#00".
+func SubtestNames(names []string) []string {
+	seen := map[string]int{}
+	result := make([]string, len(names))
+	for i, name := range names {
+		s := SubtestName(name)
+		empty := s == ""
+		for {
+			next, exists := seen[s]
+			if !empty && !exists {
+				seen[s] = 1
+				break
+			}
+			seen[s] = next + 1
+			s = fmt.Sprintf("%s#%02d", s, next)
+			empty = false
+		}
+		result[i] = s
+	}
+	return result
+}
+
+// RunPattern builds a -run pattern that selects the given full go test names
+// (e.g. "TestParse/basic_case").
+// The testing package matches each slash-separated element of a test name against the
+// corresponding element of the pattern, so names are merged level by level.
+// The resulting pattern may select a few extra combinations of the given levels,
+// but it always selects every given name.
+func RunPattern(names ...string) string {
+	var levels [][]string
+	for _, name := range names {
+		for i, elem := range strings.Split(name, "/") {
+			if i == len(levels) {
+				levels = append(levels, nil)
+			}
+			quoted := regexp.QuoteMeta(elem)
+			if !slices.Contains(levels[i], quoted) {
+				levels[i] = append(levels[i], quoted)
+			}
+		}
+	}
+
+	elems := make([]string, len(levels))
+	for i, alternatives := range levels {
+		if len(alternatives) == 1 {
+			elems[i] = "^" + alternatives[0] + "$"
+		} else {
+			elems[i] = "^(" + strings.Join(alternatives, "|") + ")$"
+		}
+	}
+	return strings.Join(elems, "/")
+}
+
+// TestCase is a test case symbol together with the name go test reports for it
+type TestCase struct {
+	Function string // name of the enclosing test function
+	Symbol   Symbol
+	TestName string // full go test name, e.g. "TestParse/basic_case"
+}
+
+// TestCases lists the test cases of the given test function symbols with their go test names.
+// The sub-benchmarks of benchmark functions are skipped, as go test -run does not run them,
+// and so are unnamed cases with placeholder names, which go test does not know.
+func TestCases(symbols []Symbol) []TestCase {
+	var cases []TestCase
+	for _, fn := range symbols {
+		if IsBenchmark(fn) {
+			continue
+		}
+		children := testCaseSymbols(fn.Children)
+		names := make([]string, len(children))
+		for i, child := range children {
+			names[i] = child.Name
+		}
+		for i, subtest := range SubtestNames(names) {
+			if children[i].Placeholder {
+				continue
+			}
+			cases = append(cases, TestCase{
+				Function: fn.Name,
+				Symbol:   children[i],
+				TestName: fn.Name + "/" + subtest,
+			})
+		}
+	}
+	return cases
+}
+
+// testCaseSymbols returns the test cases among the children of a test function, in source order,
+// with the cases of groups and tables (see Options.GroupDelimiter and Options.GroupTables) in place of them
+func testCaseSymbols(children []Symbol) []Symbol {
+	var cases []Symbol
+	for _, c := range children {
+		if isGroup(c) || isTable(c) {
+			cases = append(cases, testCaseSymbols(c.Children)...)
+		} else {
+			cases = append(cases, c)
+		}
+	}
+	slices.SortStableFunc(cases, compareCases)
+	return cases
+}
+
+// IsBenchmark reports whether a function symbol is a benchmark function
+func IsBenchmark(fn Symbol) bool {
+	return strings.HasPrefix(fn.Name, "Benchmark")
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSubtestNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{
+			name:  "spaces are replaced with underscores",
+			names: []string{"normal case", "tab\tseparated"},
+			want:  []string{"normal_case", "tab_separated"},
+		},
+		{
+			name:  "non-printable characters are escaped",
+			names: []string{"bell\a", "日本語"},
+			want:  []string{`bell\a`, "日本語"},
+		},
+		{
+			name:  "duplicate names get a suffix",
+			names: []string{"a", "a", "a"},
+			want:  []string{"a", "a#01", "a#02"},
+		},
+		{
+			name:  "names colliding after rewrite get a suffix",
+			names: []string{"a b", "a_b"},
+			want:  []string{"a_b", "a_b#01"},
+		},
+		{
+			name:  "empty names",
+			names: []string{"", ""},
+			want:  []string{"#00", "#01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SubtestNames(tt.names)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SubtestNames() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		names []string
+		want  string
+	}{
+		{
+			name:  "single test function",
+			names: []string{"TestExample"},
+			want:  "^TestExample$",
+		},
+		{
+			name:  "single test case",
+			names: []string{"TestExample/normal_case"},
+			want:  "^TestExample$/^normal_case$",
+		},
+		{
+			name:  "multiple test cases",
+			names: []string{"TestExample/normal_case", "TestExample/zero_value", "TestOther/normal_case"},
+			want:  "^(TestExample|TestOther)$/^(normal_case|zero_value)$",
+		},
+		{
+			name:  "regexp metacharacters are quoted",
+			names: []string{"TestExample/a+b_(c)"},
+			want:  `^TestExample$/^a\+b_\(c\)$`,
+		},
+		{
+			name:  "slashes in names create levels",
+			names: []string{"TestExample/a/b"},
+			want:  "^TestExample$/^a$/^b$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RunPattern(tt.names...)
+			if got != tt.want {
+				t.Errorf("RunPattern() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"go/ast"
+	"strconv"
+)
+
+// extractUnnamedCases extracts the struct literals of a table that have no name, when the table
+// has named cases or is run with t.Run. They are named as go test names them when the loop over
+// the table derives the subtest name from the index, as in t.Run(strconv.Itoa(i), ...), and get
+// placeholder names like "#2" (the index) otherwise, which comments on the cases replace.
+func (e *extractor) extractUnnamedCases(decl *ast.FuncDecl, t *table) []Symbol {
+	if _, ok := t.lit.Type.(*ast.MapType); ok {
+		return nil
+	}
+	loop := runLoop(decl.Body, t)
+	if !t.named && loop == nil && !e.isRunTable(decl, *t) {
+		return nil
+	}
+
+	structFields := extractStructFields(t.lit.Type)
+	var cases []Symbol
+	for i, elt := range t.lit.Elts {
+		caseLit, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		if _, ok := e.extractSliceElement(caseLit, structFields); ok {
+			continue // named
+		}
+
+		name, ok := indexName(loop, i)
+		if !ok {
+			name = unnamedPlaceholder(i)
+		}
+		testCase := e.createTestCaseSymbol(name, caseLit, caseLit, caseFields(caseLit, structFields, nil, e.fset))
+		testCase.Detail = unnamedDetail
+		testCase.Placeholder = !ok
+		cases = append(cases, testCase)
+	}
+	return cases
+}
+
+// unnamedDetail is the detail of test cases without a name
+const unnamedDetail = "unnamed test case"
+
+// unnamedPlaceholder returns the name shown for the unnamed case at index
+func unnamedPlaceholder(index int) string {
+	return "#" + strconv.Itoa(index)
+}
+
+// runLoop returns the loop ranging over a table that calls Run, or nil if there is none
+func runLoop(body *ast.BlockStmt, t *table) *ast.RangeStmt {
+	var loop *ast.RangeStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		rangeStmt, ok := n.(*ast.RangeStmt)
+		if loop != nil || !ok {
+			return loop == nil
+		}
+		ranged := rangeStmt.X == t.lit || t.ident != nil && isIdent(rangeStmt.X, t.ident.Name)
+		if ranged && callsRun(rangeStmt.Body) {
+			loop = rangeStmt
+		}
+		return true
+	})
+	return loop
+}
+
+// indexName evaluates the name of the subtest run in a loop over a table for the element at index,
+// when the name only depends on the index, as in t.Run(strconv.Itoa(i), ...) or t.Run(fmt.Sprint(i), ...)
+func indexName(loop *ast.RangeStmt, index int) (string, bool) {
+	if loop == nil {
+		return "", false
+	}
+	key, ok := loop.Key.(*ast.Ident)
+	if !ok || key.Name == "_" {
+		return "", false
+	}
+	var run *ast.CallExpr
+	ast.Inspect(loop.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && run == nil && isRunCall(call) {
+			run = call
+		}
+		return run == nil
+	})
+	if run == nil {
+		return "", false
+	}
+	return evalString(run.Args[0], binding{key.Name: int64(index)})
+}
@@ -3,33 +3,109 @@ package main
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"flag"
+	"io/fs"
 	"os"
+	"path/filepath"
 
-	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/config"
+	"github.com/toga4/vscode-go-tdt-outline/parser/outline"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		log.Fatalf("Usage: %s <file_path|->", os.Args[0])
+	withDiagnostics := flag.Bool("diagnostics", false, "output an object with symbols and diagnostics instead of the symbol list")
+	configPath := flag.String("config", "", "configuration file (default: "+config.Path+" in the file's directory or its parents)")
+	positionEncoding := flag.String("position-encoding", outline.DefaultPositionEncoding, `unit of "character" positions ("utf-8", "utf-16" or "utf-32")`)
+	strict := flag.Bool("strict", false, "fail on syntax errors instead of outlining the parts of the file that parse")
+	errorFormat := flag.String("error-format", "text", `format of fatal errors: "text" on stderr or "json" on stdout`)
+	flag.Parse()
+	jsonErrors := *errorFormat == "json"
+	if *errorFormat != "text" && !jsonErrors {
+		fail(false, "Invalid flags", usageErrorf("unknown error format: %s", *errorFormat))
+	}
+	if flag.NArg() < 1 {
+		fail(jsonErrors, "Invalid arguments", usageErrorf("usage: %s [-diagnostics] [-strict] [-error-format text|json] [-config path] [-position-encoding utf-8|utf-16|utf-32] <file_path|-> | flaky [flags] <file_path> [test[/case]...] | history [flags] [dir] | changed [flags] [dir] | diff [flags] <old_file|rev> <new_file>", os.Args[0]))
 	}
 
-	arg := os.Args[1]
-	var symbols []parser.Symbol
-	var err error
+	args := flag.Args()
+	switch args[0] {
+	case "flaky":
+		if err := runFlaky(args[1:]); err != nil {
+			fail(jsonErrors, "Failed to run flaky report", err)
+		}
+		return
+	case "changed":
+		if err := runChanged(args[1:]); err != nil {
+			fail(jsonErrors, "Failed to select changed tests", err)
+		}
+		return
+	case "diff":
+		if err := runDiff(args[1:]); err != nil {
+			fail(jsonErrors, "Failed to diff", err)
+		}
+		return
+	case "history":
+		if err := runHistory(args[1:]); err != nil {
+			fail(jsonErrors, "Failed to run history report", err)
+		}
+		return
+	}
+
+	arg := args[0]
+	opts, err := loadOptions(*configPath, arg)
+	if err != nil {
+		fail(jsonErrors, "Failed to load config", err)
+	}
+	opts.PositionEncoding = *positionEncoding
+	opts.Strict = *strict
+	if err := opts.Validate(); err != nil {
+		fail(jsonErrors, "Invalid options", &usageError{err: err})
+	}
+
+	var result *outline.Result
 
 	if arg == "-" {
-		// Read from stdin
-		symbols, err = parser.Parse("<stdin>", os.Stdin)
+		// Read from stdin, with the working directory as the package directory
+		opts.PackageDir = "."
+		result, err = outline.Parse("<stdin>", os.Stdin, opts)
 	} else {
 		// Read from file
-		symbols, err = parser.ParseFile(arg)
+		result, err = outline.ParseFile(arg, opts)
 	}
 	if err != nil {
-		log.Fatalf("Failed to parse: %v", err)
+		fail(jsonErrors, "Failed to parse", err)
 	}
 
-	if err := json.NewEncoder(os.Stdout).Encode(symbols); err != nil {
-		log.Fatalf("Failed to encode symbols: %v", err)
+	var output any = result.Symbols
+	if *withDiagnostics {
+		output = result
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(output); err != nil {
+		fail(jsonErrors, "Failed to encode symbols", err)
+	}
+}
+
+// loadOptions loads the parser options from the given configuration file, or from the
+// configuration file found from the directory of arg (the working directory for stdin)
+func loadOptions(configPath, arg string) (outline.Options, error) {
+	var c *config.Config
+	var err error
+	if configPath != "" {
+		c, err = config.Load(configPath)
+	} else {
+		dir := "."
+		if arg != "-" {
+			dir = filepath.Dir(arg)
+		}
+		c, err = config.LoadFor(dir)
+	}
+	var pathErr *fs.PathError
+	if err != nil && !errors.As(err, &pathErr) {
+		return outline.Options{}, &usageError{err: err} // invalid configuration
+	}
+	if err != nil {
+		return outline.Options{}, err
 	}
+	return c.ParserOptions(), nil
 }
@@ -0,0 +1,166 @@
+// Package outline extracts the test cases of table-driven Go tests as symbols in VS Code's
+// document symbol format, along with diagnostics on the test tables.
+//
+// The JSON form of Result and Symbol is versioned by SchemaVersion.
+package outline
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/internal/parser"
+)
+
+// SchemaVersion is the version of the JSON form of Result and Symbol.
+// Fields may be added within a version, but are not removed or changed.
+const SchemaVersion = parser.SchemaVersion
+
+type (
+	// Options configures parsing. The zero value recognizes every supported table pattern,
+	// reports every diagnostic with its default severity and outlines files with syntax errors
+	// as far as they parse.
+	Options = parser.Options
+
+	// Result holds the symbols and diagnostics of a file
+	Result = parser.Result
+
+	// Symbol is a test function or test case in VS Code's document symbol format
+	Symbol = parser.Symbol
+
+	// Range is a range in a file
+	Range = parser.Range
+
+	// Position is a 0-indexed position in a file
+	Position = parser.Line
+
+	// Diagnostic is a problem found in a test table
+	Diagnostic = parser.Diagnostic
+
+	// Fix is a suggested change resolving a diagnostic
+	Fix = parser.Fix
+
+	// TextEdit replaces the text in a range
+	TextEdit = parser.TextEdit
+
+	// Pattern describes a user-defined test case pattern, as in the configuration file
+	Pattern = parser.Pattern
+
+	// Recognizer finds the test cases of a test function written in a particular pattern.
+	// Custom recognizers are given in Options.CustomRecognizers.
+	Recognizer = parser.Recognizer
+
+	// TestFunc is a test function passed to recognizers
+	TestFunc = parser.TestFunc
+
+	// ParseError is returned in strict mode for files with syntax errors
+	ParseError = parser.ParseError
+)
+
+// Symbol kinds, as in VS Code's SymbolKind enumeration
+const (
+	SymbolKindNamespace = parser.SymbolKindNamespace // group of test cases (see Options.GroupDelimiter)
+	SymbolKindFunction  = parser.SymbolKindFunction  // test function
+	SymbolKindVariable  = parser.SymbolKindVariable  // test table (see Options.GroupTables)
+	SymbolKindStruct    = parser.SymbolKindStruct    // test case
+)
+
+// Diagnostic severities, as in VS Code's DiagnosticSeverity enumeration
+const (
+	SeverityError       = parser.SeverityError
+	SeverityWarning     = parser.SeverityWarning
+	SeverityInformation = parser.SeverityInformation
+	SeverityHint        = parser.SeverityHint
+)
+
+// Rule IDs of diagnostics, as used in Options.Rules
+const (
+	RuleDuplicateName      = parser.RuleDuplicateName
+	RuleNameCollision      = parser.RuleNameCollision
+	RuleEmptyName          = parser.RuleEmptyName
+	RuleMissingName        = parser.RuleMissingName
+	RuleNameWhitespace     = parser.RuleNameWhitespace
+	RuleInconsistentPrefix = parser.RuleInconsistentPrefix
+	RuleMixedElements      = parser.RuleMixedElements
+	RuleUnusedTable        = parser.RuleUnusedTable
+	RuleDuplicateCase      = parser.RuleDuplicateCase
+	RuleDuplicateInputs    = parser.RuleDuplicateInputs
+	RuleSyntaxError        = parser.RuleSyntaxError // not configurable
+)
+
+// Position encodings for Options.PositionEncoding
+const (
+	EncodingUTF8  = parser.EncodingUTF8
+	EncodingUTF16 = parser.EncodingUTF16
+	EncodingUTF32 = parser.EncodingUTF32
+
+	DefaultPositionEncoding = parser.DefaultPositionEncoding // used when Options.PositionEncoding is empty
+)
+
+// DefaultMaxExpansions is used when Options.MaxExpansions is zero
+const DefaultMaxExpansions = parser.DefaultMaxExpansions
+
+// Pattern kinds for Pattern.Kind
+const (
+	PatternCall        = parser.PatternCall
+	PatternConstructor = parser.PatternConstructor
+	PatternWrapper     = parser.PatternWrapper
+)
+
+// Recognizer names for Options.Recognizers
+const (
+	RecognizerSlice       = parser.RecognizerSlice
+	RecognizerMap         = parser.RecognizerMap
+	RecognizerRangeInline = parser.RecognizerRangeInline
+	RecognizerVarDecl     = parser.RecognizerVarDecl
+	RecognizerRunner      = parser.RecognizerRunner
+	RecognizerLoop        = parser.RecognizerLoop
+)
+
+// DefaultNameFields are the test case name fields used when Options.NameFields is nil
+var DefaultNameFields = parser.DefaultNameFields
+
+// Parse parses Go source code read from src. filename is used for positions and error messages.
+func Parse(filename string, src io.Reader, opts Options) (*Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+	return parser.Analyze(filename, src, opts)
+}
+
+// ParseFile parses the Go file at path
+func ParseFile(path string, opts Options) (*Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+	return parser.AnalyzeFile(path, opts)
+}
+
+// ParseDir parses the _test.go files in dir, not including subdirectories.
+// The results are keyed by file path. If a file cannot be parsed, the results
+// of the files parsed so far and the error are returned.
+func ParseDir(dir string, opts Options) (map[string]*Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := map[string]*Result{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		result, err := parser.AnalyzeFile(path, opts)
+		if err != nil {
+			return results, err
+		}
+		results[path] = result
+	}
+	return results, nil
+}
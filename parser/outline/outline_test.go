@@ -0,0 +1,221 @@
+package outline_test
+
+import (
+	"errors"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toga4/vscode-go-tdt-outline/parser/outline"
+)
+
+const testdata = "../internal/parser/testdata"
+
+// caseNames returns the test case names of each test function
+func caseNames(symbols []outline.Symbol) map[string][]string {
+	names := map[string][]string{}
+	for _, fn := range symbols {
+		for _, c := range fn.Children {
+			names[fn.Name] = append(names[fn.Name], c.Name)
+		}
+	}
+	return names
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import "testing"
+
+func TestX(t *testing.T) {
+	tests := []struct {
+		label string
+		in    int
+	}{
+		{label: "one", in: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, nil)
+	}
+
+	for name := range map[string]int{"two": 2} {
+		t.Run(name, nil)
+	}
+}
+`
+
+	tests := []struct {
+		name string
+		opts outline.Options
+		want map[string][]string
+	}{
+		{
+			name: "default options",
+			want: map[string][]string{"TestX": {"#0", "two"}},
+		},
+		{
+			name: "custom name fields",
+			opts: outline.Options{NameFields: []string{"Label"}},
+			want: map[string][]string{"TestX": {"one", "two"}},
+		},
+		{
+			name: "enabled recognizers",
+			opts: outline.Options{NameFields: []string{"label"}, Recognizers: []string{outline.RecognizerSlice}},
+			want: map[string][]string{"TestX": {"one"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := outline.Parse("x_test.go", strings.NewReader(src), tt.opts)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got.Version != outline.SchemaVersion {
+				t.Errorf("Parse() version = %d, want %d", got.Version, outline.SchemaVersion)
+			}
+			if diff := cmp.Diff(tt.want, caseNames(got.Symbols)); diff != "" {
+				t.Errorf("Parse() case names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	if _, err := outline.ParseFile(filepath.Join(testdata, "basic_table_test.go"), outline.Options{Recognizers: []string{"struct"}}); err == nil {
+		t.Error("ParseFile() error = nil, want an error for an unknown recognizer")
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	t.Parallel()
+
+	got, err := outline.ParseDir(testdata, outline.Options{})
+	if err != nil {
+		t.Fatalf("ParseDir() error = %v", err)
+	}
+
+	var files []string
+	for path := range got {
+		files = append(files, filepath.Base(path))
+	}
+	if !slices.Contains(files, "basic_table_test.go") || slices.Contains(files, "map_test_cases.go") {
+		t.Errorf("ParseDir() files = %v, want the _test.go files only", files)
+	}
+
+	basic := got[filepath.Join(testdata, "basic_table_test.go")]
+	if diff := cmp.Diff(map[string][]string{"TestExample": {"normal case", "zero value"}}, caseNames(basic.Symbols)); diff != "" {
+		t.Errorf("ParseDir() case names mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseDirStrict(t *testing.T) {
+	t.Parallel()
+
+	_, err := outline.ParseDir(testdata, outline.Options{Strict: true})
+	var parseErr *outline.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("ParseDir() error = %v, want *ParseError", err)
+	}
+	if got, want := filepath.Base(parseErr.Filename), "syntax_error_test.go"; got != want {
+		t.Errorf("ParseError.Filename = %q, want %q", got, want)
+	}
+}
+
+// checkRecognizer recognizes test cases written as check(t, "name", ...) calls
+type checkRecognizer struct{}
+
+func (checkRecognizer) Name() string { return "check" }
+
+func (checkRecognizer) Recognize(fn *outline.TestFunc) []outline.Symbol {
+	var cases []outline.Symbol
+	ast.Inspect(fn.Decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+		if ident, ok := call.Fun.(*ast.Ident); !ok || ident.Name != "check" {
+			return true
+		}
+		if lit, ok := call.Args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			name, _ := strconv.Unquote(lit.Value)
+			cases = append(cases, fn.TestCase(name, call, lit))
+		}
+		return true
+	})
+	return cases
+}
+
+func TestCustomRecognizer(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+import "testing"
+
+func TestDSL(t *testing.T) {
+	check(t, "adds", 1, 2)
+	for _, tt := range []struct{ name string }{{name: "table"}} {
+		t.Run(tt.name, nil)
+	}
+	check(t, "subtracts", 3, 1)
+}
+`
+
+	tests := []struct {
+		name    string
+		opts    outline.Options
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "with built-in recognizers",
+			opts: outline.Options{CustomRecognizers: []outline.Recognizer{checkRecognizer{}}},
+			want: map[string][]string{"TestDSL": {"adds", "table", "subtracts"}},
+		},
+		{
+			name: "enabled by name",
+			opts: outline.Options{CustomRecognizers: []outline.Recognizer{checkRecognizer{}}, Recognizers: []string{"check"}},
+			want: map[string][]string{"TestDSL": {"adds", "subtracts"}},
+		},
+		{
+			name: "disabled by name",
+			opts: outline.Options{CustomRecognizers: []outline.Recognizer{checkRecognizer{}}, Recognizers: []string{outline.RecognizerRangeInline}},
+			want: map[string][]string{"TestDSL": {"table"}},
+		},
+		{
+			name:    "duplicate name",
+			opts:    outline.Options{CustomRecognizers: []outline.Recognizer{checkRecognizer{}, checkRecognizer{}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := outline.Parse("dsl_test.go", strings.NewReader(src), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, caseNames(got.Symbols)); diff != "" {
+				t.Errorf("Parse() case names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -12,9 +12,40 @@ interface GoSymbol {
     start: { line: number; character: number };
     end: { line: number; character: number };
   };
+  selectionRange: {
+    start: { line: number; character: number };
+    end: { line: number; character: number };
+  };
   children: GoSymbol[];
 }
 
+// Type definition for diagnostics reported by Go analysis tool
+interface GoDiagnostic {
+  range: {
+    start: { line: number; character: number };
+    end: { line: number; character: number };
+  };
+  severity: number;
+  rule: string;
+  message: string;
+}
+
+// Type definition for JSON output from Go analysis tool with -diagnostics
+interface GoParseResult {
+  version: number;
+  symbols: GoSymbol[];
+  diagnostics: GoDiagnostic[];
+}
+
+// Type definition for JSON error output from Go analysis tool with -error-format json
+interface GoError {
+  error: {
+    kind: "usage" | "io" | "syntax" | "internal";
+    message: string;
+    file?: string;
+  };
+}
+
 // Configuration interface
 interface ExtensionConfig {
   timeout: number;
@@ -34,8 +65,15 @@ export async function activate(context: vscode.ExtensionContext): Promise<Extens
 
   outputChannel.appendLine("Go TDD Outline extension is being activated...");
 
+  // Create Diagnostic Collection for test table diagnostics
+  const diagnosticCollection = vscode.languages.createDiagnosticCollection("go-tdt-outline");
+  context.subscriptions.push(diagnosticCollection);
+  context.subscriptions.push(
+    vscode.workspace.onDidCloseTextDocument((document) => diagnosticCollection.delete(document.uri)),
+  );
+
   try {
-    const goTddOutlineProvider = new GoTddOutlineProvider(context, outputChannel);
+    const goTddOutlineProvider = new GoTddOutlineProvider(context, outputChannel, diagnosticCollection);
 
     // Register DocumentSymbolProvider for Go language files
     const disposable = vscode.languages.registerDocumentSymbolProvider(
@@ -60,10 +98,16 @@ export class GoTddOutlineProvider implements vscode.DocumentSymbolProvider {
   private readonly parserPath: string;
   private readonly config: ExtensionConfig;
   private readonly outputChannel: vscode.OutputChannel;
+  private readonly diagnosticCollection: vscode.DiagnosticCollection;
   private parserExists = false;
 
-  constructor(context: vscode.ExtensionContext, outputChannel: vscode.OutputChannel) {
+  constructor(
+    context: vscode.ExtensionContext,
+    outputChannel: vscode.OutputChannel,
+    diagnosticCollection: vscode.DiagnosticCollection,
+  ) {
     this.outputChannel = outputChannel;
+    this.diagnosticCollection = diagnosticCollection;
 
     // Load configuration
     this.config = this.loadConfiguration();
@@ -91,10 +135,14 @@ export class GoTddOutlineProvider implements vscode.DocumentSymbolProvider {
 
   private runParser(
     input: string,
+    cwd: string,
     token: vscode.CancellationToken,
   ): Promise<{ stdout: string; stderr: string } | null> {
     return new Promise((resolve, reject) => {
-      const proc = cp.spawn(this.parserPath, ["-"]);
+      const proc = cp.spawn(this.parserPath, ["-diagnostics", "-error-format", "json", "-position-encoding", "utf-16", "-"], {
+        // The parser finds the project configuration from its working directory when reading stdin
+        cwd,
+      });
       let stdout = "";
       let stderr = "";
 
@@ -127,7 +175,7 @@ export class GoTddOutlineProvider implements vscode.DocumentSymbolProvider {
         if (code === 0) {
           resolve({ stdout, stderr });
         } else {
-          reject(new Error(`Parser exited with code ${code}: ${stderr}`));
+          reject(new Error(`Parser exited with code ${code}: ${this.errorMessage(stdout) ?? stderr}`));
         }
       });
 
@@ -167,21 +215,25 @@ export class GoTddOutlineProvider implements vscode.DocumentSymbolProvider {
     }
 
     try {
-      const result = await this.runParser(document.getText(), token);
+      const result = await this.runParser(document.getText(), path.dirname(document.fileName), token);
       if (!result) {
         return [];
       }
 
-      const goSymbols: GoSymbol[] = JSON.parse(result.stdout);
-      if (!goSymbols || !Array.isArray(goSymbols)) {
+      const parseResult: GoParseResult = JSON.parse(result.stdout);
+      if (!parseResult || !Array.isArray(parseResult.symbols)) {
         return [];
       }
 
+      // Table diagnostics only concern test files
+      const diagnostics = document.fileName.endsWith("_test.go") ? (parseResult.diagnostics ?? []) : [];
+      this.diagnosticCollection.set(document.uri, this.convertToVSCodeDiagnostics(diagnostics));
+
       if (result.stderr?.trim()) {
         this.outputChannel.appendLine(`Parser stderr: ${result.stderr}`);
       }
 
-      const vsCodeSymbols = this.convertToVSCodeSymbols(goSymbols);
+      const vsCodeSymbols = this.convertToVSCodeSymbols(parseResult.symbols);
       return vsCodeSymbols;
     } catch (error) {
       this.outputChannel.appendLine(`Error: ${error}`);
@@ -198,13 +250,18 @@ export class GoTddOutlineProvider implements vscode.DocumentSymbolProvider {
         new vscode.Position(s.range.start.line, s.range.start.character),
         new vscode.Position(s.range.end.line, s.range.end.character),
       );
+      // Range of the symbol name, so that navigation lands on the name rather than the whole literal
+      const selectionRange = new vscode.Range(
+        new vscode.Position(s.selectionRange.start.line, s.selectionRange.start.character),
+        new vscode.Position(s.selectionRange.end.line, s.selectionRange.end.character),
+      );
 
       const symbol = new vscode.DocumentSymbol(
         s.name,
         s.detail,
         s.kind as vscode.SymbolKind, // SymbolKind numbers are aligned with Go side
         range,
-        range, // Use same range for selectionRange
+        selectionRange,
       );
 
       if (s.children && s.children.length > 0) {
@@ -214,6 +271,40 @@ export class GoTddOutlineProvider implements vscode.DocumentSymbolProvider {
       return symbol;
     });
   }
+
+  /**
+   * Extract the message of the JSON error output from Go tool, if any
+   */
+  private errorMessage(stdout: string): string | undefined {
+    try {
+      const output = JSON.parse(stdout) as GoError;
+      return output.error ? `${output.error.kind} error: ${output.error.message}` : undefined;
+    } catch {
+      return undefined;
+    }
+  }
+
+  /**
+   * Convert diagnostics from Go tool to VSCode Diagnostic[]
+   */
+  private convertToVSCodeDiagnostics(goDiagnostics: GoDiagnostic[]): vscode.Diagnostic[] {
+    // Syntax errors are already reported by gopls
+    return goDiagnostics.filter((d) => d.rule !== "syntax-error").map((d) => {
+      const range = new vscode.Range(
+        new vscode.Position(d.range.start.line, d.range.start.character),
+        new vscode.Position(d.range.end.line, d.range.end.character),
+      );
+
+      const diagnostic = new vscode.Diagnostic(
+        range,
+        d.message,
+        d.severity as vscode.DiagnosticSeverity, // DiagnosticSeverity numbers are aligned with Go side
+      );
+      diagnostic.source = "go-tdt-outline";
+      diagnostic.code = d.rule;
+      return diagnostic;
+    });
+  }
 }
 
 export function deactivate() {
@@ -17,7 +17,11 @@ suite("Snapshot Tests", () => {
     provider = api.documentSymbolProvider;
   });
 
-  for (const file of fs.readdirSync(testFileDir)) {
+  for (const entry of fs.readdirSync(testFileDir, { withFileTypes: true })) {
+    if (entry.isDirectory()) {
+      continue;
+    }
+    const file = entry.name;
     test(file, async () => {
       const testFilePath = path.join(testFileDir, file);
       const document = await vscode.workspace.openTextDocument(testFilePath);